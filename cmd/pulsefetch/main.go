@@ -1,17 +1,205 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"runtime/debug"
+	"slices"
+	"strings"
 
-	"pulsefetch/internal/config"
+	"pulsefetch/config"
 	"pulsefetch/internal/fetcher"
 	"pulsefetch/internal/ui"
+	"pulsefetch/module"
 )
 
+// version is set at build time with -ldflags "-X main.version=..."
+var version = ""
+
+// setFlags collects repeated --set key=value flags.
+type setFlags map[string]any
+
+func (s setFlags) String() string { return "" }
+
+func (s setFlags) Set(v string) error {
+	key, value, ok := strings.Cut(v, "=")
+	key = strings.ToLower(strings.TrimSpace(key))
+	if !ok || key == "" {
+		return fmt.Errorf("want key=value, got %q", v)
+	}
+	s[key] = strings.TrimSpace(value)
+	return nil
+}
+
+// moduleNames returns every name --only and --hide accept.
+func moduleNames() []string {
+	var names []string
+	for _, m := range module.Modules() {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+// showNames returns every name accepted in a show_<name> config key.
+func showNames() []string {
+	return append(moduleNames(), fetcher.ModuleOptions()...)
+}
+
+// splitModules parses a comma-separated module list, rejecting unknown names.
+func splitModules(list string) ([]string, error) {
+	known := moduleNames()
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(known, name) {
+			return nil, fmt.Errorf("unknown module %q (known: %s)", name, strings.Join(known, ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func getVersion() string {
+	if version != "" {
+		return version
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		return bi.Main.Version
+	}
+	return "dev"
+}
+
+// configOptions validates show_ keys against the registered modules and
+// theme and colour values against what the ui accepts.
+func configOptions(path string, overrides map[string]any) config.Options {
+	return config.Options{
+		Path:       path,
+		Overrides:  overrides,
+		Modules:    showNames(),
+		Themes:     ui.ThemeNames(),
+		ParseColor: ui.ParseColor,
+	}
+}
+
+// configCheck implements "pulsefetch config check": it validates the config
+// file and reports every problem with its line number.
+func configCheck(args []string) int {
+	fs := flag.NewFlagSet("config check", flag.ExitOnError)
+	configPath := fs.String("config", "", "check this file instead of the default locations")
+	fs.Parse(args)
+
+	path, problems, err := config.Check(configOptions(*configPath, nil))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if path == "" {
+		fmt.Println("No config file found; using defaults. Searched:")
+		for _, p := range config.SearchPaths() {
+			fmt.Println("  " + p)
+		}
+	}
+	// Problems can come from PULSEFETCH_* variables even without a file
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		return 1
+	}
+	if path != "" {
+		fmt.Printf("%s: OK\n", path)
+	}
+	return 0
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: pulsefetch [flags]
+       pulsefetch config check [--config <path>]
+
+Shows system information next to a logo. Flags override the config file
+for this run only. "config check" validates the config file.
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
 func main() {
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "check" {
+		os.Exit(configCheck(os.Args[3:]))
+	}
+
+	sets := setFlags{}
+	configPath := flag.String("config", "", "read this config file instead of the default locations")
+	noLogo := flag.Bool("no-logo", false, "don't show a logo or image")
+	logoName := flag.String("logo", "", "logo name (e.g. arch, arch_small) or path to an ASCII art file")
+	imagePath := flag.String("image", "", "show this image instead of a logo")
+	only := flag.String("only", "", "show only these modules, comma-separated (e.g. os,kernel,cpu)")
+	hide := flag.String("hide", "", "hide these modules, comma-separated (e.g. gpu,packages)")
+	flag.Var(sets, "set", "set a config key, e.g. --set theme=nord (repeatable)")
+	noCache := flag.Bool("no-cache", false, "ignore and don't update the module cache")
+	format := flag.String("format", "text", `output format: "text" or "json"`)
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Println("pulsefetch", getVersion())
+		return
+	}
+	if flag.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Unexpected argument %q\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown format %q (want \"text\" or \"json\")\n", *format)
+		os.Exit(2)
+	}
+
+	// Per-run overrides, layered over the config file. Dedicated flags win
+	// over --set.
+	overrides := map[string]any(sets)
+	if *only != "" {
+		names, err := splitModules(*only)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "--only: %v\n", err)
+			os.Exit(2)
+		}
+		for _, name := range moduleNames() {
+			overrides["show_"+name] = slices.Contains(names, name)
+		}
+	}
+	if *hide != "" {
+		names, err := splitModules(*hide)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "--hide: %v\n", err)
+			os.Exit(2)
+		}
+		for _, name := range names {
+			overrides["show_"+name] = false
+		}
+	}
+	if *logoName != "" {
+		overrides["logo"] = *logoName
+	}
+	if *imagePath != "" {
+		overrides["image_path"] = *imagePath
+	}
+	if *noLogo {
+		overrides["image_mode"] = "none"
+	}
+	if *noCache {
+		overrides["cache"] = false
+	}
+
 	// Load Configuration
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadFrom(configOptions(*configPath, overrides))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
@@ -24,8 +212,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Machine-readable output: no logo, no styling
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(info); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing JSON: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := ui.ApplyTheme(cfg, info); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading theme: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Get Logo
-	logo := ui.GetLogo(cfg, info)
+	logo, err := ui.GetLogo(cfg, info)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading logo: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Render Output
 	ui.Render(cfg, info, logo)
@@ -0,0 +1,454 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// enums lists the values accepted by keys that only take a fixed set.
+var enums = map[string][]string{
+	"image_mode":    {"ascii", "none"},
+	"logo_size":     {"full", "small"},
+	"palette_style": {"blocks", "circles", "gradient"},
+}
+
+// Problem is one mistake found in the configuration. Line is 0 when it
+// didn't come from a file, e.g. a --set override.
+type Problem struct {
+	File string
+	Line int
+	Key  string
+	Msg  string
+}
+
+func (p Problem) String() string {
+	switch {
+	case p.File != "" && p.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.msg())
+	case p.File != "":
+		return fmt.Sprintf("%s: %s", p.File, p.msg())
+	}
+	return p.msg()
+}
+
+func (p Problem) msg() string {
+	if p.Key == "" {
+		return p.Msg
+	}
+	return p.Key + ": " + p.Msg
+}
+
+// ValidationError is returned by LoadFrom when the configuration has
+// problems.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = p.String()
+	}
+	return "invalid configuration:\n  " + strings.Join(lines, "\n  ")
+}
+
+// schema maps every known key to the kind of value it takes. show_<name>
+// keys are booleans for each name in shows; a nil shows accepts any name.
+// themes and parseColor, when set, validate the theme and colour values.
+type schema struct {
+	kinds      map[string]reflect.Kind
+	shows      []string
+	themes     []string
+	parseColor func(string) (string, error)
+}
+
+func newSchema(opts Options) schema {
+	s := schema{
+		kinds:      make(map[string]reflect.Kind),
+		shows:      opts.Modules,
+		themes:     opts.Themes,
+		parseColor: opts.ParseColor,
+	}
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			tag := f.Tag.Get("mapstructure")
+			if tag == "" || tag == "-" {
+				continue
+			}
+			s.kinds[prefix+tag] = f.Type.Kind()
+			if f.Type.Kind() == reflect.Struct {
+				walk(f.Type, prefix+tag+".")
+			}
+		}
+	}
+	walk(reflect.TypeFor[Config](), "")
+	return s
+}
+
+// lookup returns the kind of value key takes.
+func (s schema) lookup(key string) (reflect.Kind, bool) {
+	if name, ok := strings.CutPrefix(key, "show_"); ok && !strings.Contains(name, ".") {
+		return reflect.Bool, s.shows == nil || slices.Contains(s.shows, name)
+	}
+	k, ok := s.kinds[key]
+	return k, ok
+}
+
+// known lists every key, for suggestions.
+func (s schema) known() []string {
+	var keys []string
+	for k, kind := range s.kinds {
+		if kind != reflect.Struct {
+			keys = append(keys, k)
+		}
+	}
+	for _, name := range s.shows {
+		keys = append(keys, "show_"+name)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unknown describes a key that isn't in the schema, suggesting the closest
+// known one.
+func (s schema) unknown(key string) string {
+	candidates := s.known()
+	for k, kind := range s.kinds {
+		if kind == reflect.Struct {
+			candidates = append(candidates, k)
+		}
+	}
+
+	if best := closest(key, candidates); best != "" {
+		return fmt.Sprintf("unknown key (did you mean %q?)", best)
+	}
+	return "unknown key"
+}
+
+// closest returns the candidate nearest to s, or "" if none is close
+// enough to be a likely typo.
+func closest(s string, candidates []string) string {
+	best, bestDist := "", 0
+	for _, c := range candidates {
+		if d := editDistance(s, c); best == "" || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if best != "" && bestDist <= max(2, len(s)/3) {
+		return best
+	}
+	return ""
+}
+
+// checkValue validates a value for key, which must be in the schema. kind
+// is the TOML type of the value, or Invalid when it came from a string
+// override that viper will convert.
+func (s schema) checkValue(key string, kind unstable.Kind, value string) string {
+	want, _ := s.lookup(key)
+	switch want {
+	case reflect.Struct:
+		if kind != unstable.InlineTable {
+			return "expected a table"
+		}
+		return ""
+	case reflect.String:
+		if kind != unstable.Invalid && kind != unstable.String {
+			return fmt.Sprintf("expected a quoted string, got %s (write %q)", describe(kind, value), value)
+		}
+	case reflect.Int:
+		if kind == unstable.Invalid {
+			if _, err := strconv.Atoi(value); err != nil {
+				return fmt.Sprintf("expected an integer, got %q", value)
+			}
+		} else if kind != unstable.Integer {
+			return fmt.Sprintf("expected an integer, got %s", describe(kind, value))
+		}
+	case reflect.Bool:
+		if kind == unstable.Invalid {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Sprintf("expected true or false, got %q", value)
+			}
+		} else if kind != unstable.Bool {
+			return fmt.Sprintf("expected true or false, got %s", describe(kind, value))
+		}
+	}
+	if allowed, ok := enums[key]; ok && !slices.Contains(allowed, value) {
+		return fmt.Sprintf("%q is not one of %s", value, quoteList(allowed))
+	}
+	if key == "theme" && value != "" && s.themes != nil && !slices.Contains(s.themes, strings.ToLower(value)) {
+		if best := closest(strings.ToLower(value), s.themes); best != "" {
+			return fmt.Sprintf("unknown theme %q (did you mean %q?)", value, best)
+		}
+		return fmt.Sprintf("unknown theme %q (one of %s)", value, quoteList(s.themes))
+	}
+	if s.parseColor != nil && value != "" && (key == "accent" || strings.HasPrefix(key, "colors.")) {
+		// accent also takes "auto" and "none"
+		if lower := strings.ToLower(value); key == "accent" && (lower == "auto" || lower == "none") {
+			return ""
+		}
+		if _, err := s.parseColor(value); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// describe names a TOML value and its type for error messages.
+func describe(k unstable.Kind, value string) string {
+	switch k {
+	case unstable.String:
+		return fmt.Sprintf("string %q", value)
+	case unstable.Array, unstable.InlineTable:
+		return kindName(k)
+	}
+	return kindName(k) + " " + value
+}
+
+func kindName(k unstable.Kind) string {
+	switch k {
+	case unstable.String:
+		return "string"
+	case unstable.Integer:
+		return "integer"
+	case unstable.Float:
+		return "float"
+	case unstable.Bool:
+		return "boolean"
+	case unstable.Array:
+		return "array"
+	case unstable.InlineTable:
+		return "table"
+	}
+	return "date/time"
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Check validates the configuration LoadFrom would read with opts: the
+// config file it finds, if any, and the overrides. It returns the file
+// checked. The error is only for files that can't be read.
+func Check(opts Options) (string, []Problem, error) {
+	path, err := findFile(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	s := newSchema(opts)
+
+	var problems []Problem
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return path, nil, fmt.Errorf("config file: %w", err)
+		}
+		problems = checkTOML(path, data, s)
+	}
+	problems = append(problems, checkEnv(os.Environ(), s)...)
+	return path, append(problems, checkOverrides(opts.Overrides, s)...), nil
+}
+
+func checkTOML(path string, data []byte, s schema) []Problem {
+	// Syntax errors first; go-toml reports their position
+	var doc map[string]any
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		var derr *toml.DecodeError
+		if errors.As(err, &derr) {
+			line, _ := derr.Position()
+			if key, word, ok := bareWord(data, line); ok {
+				msg := fmt.Sprintf("string values must be quoted (write %q)", word)
+				switch kind, _ := s.lookup(key); kind {
+				case reflect.Bool:
+					msg = fmt.Sprintf("expected true or false, got %s", word)
+				case reflect.Int:
+					msg = fmt.Sprintf("expected an integer, got %s", word)
+				}
+				return []Problem{{File: path, Line: line, Key: key, Msg: msg}}
+			}
+			return []Problem{{File: path, Line: line, Msg: strings.TrimPrefix(derr.Error(), "toml: ")}}
+		}
+		return []Problem{{File: path, Msg: err.Error()}}
+	}
+
+	var problems []Problem
+	p := unstable.Parser{}
+	p.Reset(data)
+	table := ""
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			key, line := joinKey(&p, expr.Key())
+			table = key
+			if kind, ok := s.lookup(key); !ok || kind != reflect.Struct {
+				msg := s.unknown(key)
+				if ok {
+					msg = "not a table"
+				}
+				problems = append(problems, Problem{File: path, Line: line, Key: "[" + key + "]", Msg: msg})
+			}
+		case unstable.KeyValue:
+			// Keys under an unknown table were already reported
+			if _, tableOK := s.lookup(table); table == "" || tableOK {
+				problems = append(problems, checkKeyValue(path, &p, table, expr, s)...)
+			}
+		}
+	}
+	return problems
+}
+
+// checkKeyValue validates one key = value expression under table,
+// descending into inline tables like colors = { key = "39" }.
+func checkKeyValue(path string, p *unstable.Parser, table string, expr *unstable.Node, s schema) []Problem {
+	key, line := joinKey(p, expr.Key())
+	if table != "" {
+		key = table + "." + key
+	}
+	if _, ok := s.lookup(key); !ok {
+		return []Problem{{File: path, Line: line, Key: key, Msg: s.unknown(key)}}
+	}
+	v := expr.Value()
+	if msg := s.checkValue(key, v.Kind, string(v.Data)); msg != "" {
+		return []Problem{{File: path, Line: line, Key: key, Msg: msg}}
+	}
+	var problems []Problem
+	if v.Kind == unstable.InlineTable {
+		for it := v.Children(); it.Next(); {
+			problems = append(problems, checkKeyValue(path, p, key, it.Node(), s)...)
+		}
+	}
+	return problems
+}
+
+// bareWordRe matches a line assigning an unquoted word, like
+// image_mode = none.
+var bareWordRe = regexp.MustCompile(`^\s*([A-Za-z0-9_.-]+)\s*=\s*([A-Za-z][^\s"'#]*)\s*(#.*)?$`)
+
+// bareWord reports whether the given line of data assigns an unquoted
+// string, the most common syntax error in hand-edited configs.
+func bareWord(data []byte, line int) (key, word string, ok bool) {
+	lines := strings.Split(string(data), "\n")
+	if line < 1 || line > len(lines) {
+		return "", "", false
+	}
+	m := bareWordRe.FindStringSubmatch(lines[line-1])
+	if m == nil {
+		return "", "", false
+	}
+	return strings.ToLower(m[1]), m[2], true
+}
+
+// joinKey returns a dotted key and the line it starts on.
+func joinKey(p *unstable.Parser, it unstable.Iterator) (string, int) {
+	var parts []string
+	line := 0
+	for it.Next() {
+		n := it.Node()
+		if line == 0 {
+			line = p.Shape(n.Raw).Start.Line
+		}
+		parts = append(parts, strings.ToLower(string(n.Data)))
+	}
+	return strings.Join(parts, "."), line
+}
+
+// checkOverrides validates keys set outside the file, e.g. with --set.
+func checkOverrides(overrides map[string]any, s schema) []Problem {
+	var problems []Problem
+	for key, v := range overrides {
+		if _, ok := s.lookup(key); !ok {
+			problems = append(problems, Problem{Key: key, Msg: s.unknown(key)})
+			continue
+		}
+		if str, ok := v.(string); ok {
+			if msg := s.checkValue(key, unstable.Invalid, str); msg != "" {
+				problems = append(problems, Problem{Key: key, Msg: msg})
+			}
+		}
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems
+}
+
+// checkEnv validates the PULSEFETCH_<KEY> variables in environ, reporting
+// problems under the variable's name.
+func checkEnv(environ []string, s schema) []Problem {
+	// Variable names for every key, e.g. PULSEFETCH_COLORS_KEY
+	vars := make(map[string]string)
+	for _, key := range s.known() {
+		vars[envName(key)] = key
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+
+	var problems []Problem
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		rest, ok := strings.CutPrefix(name, envPrefix+"_")
+		if !ok || rest == "CONFIG" {
+			continue
+		}
+		key, ok := vars[name]
+		if module, show := strings.CutPrefix(rest, "SHOW_"); show && !ok {
+			key = "show_" + strings.ToLower(module)
+			_, ok = s.lookup(key)
+		}
+		if !ok {
+			msg := "unknown variable"
+			if best := closest(name, names); best != "" {
+				msg = fmt.Sprintf("unknown variable (did you mean %s?)", best)
+			}
+			problems = append(problems, Problem{Key: name, Msg: msg})
+			continue
+		}
+		if msg := s.checkValue(key, unstable.Invalid, value); msg != "" {
+			problems = append(problems, Problem{Key: name, Msg: msg})
+		}
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems
+}
+
+// envName is the environment variable that overrides key.
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
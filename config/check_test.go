@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testColor stands in for the ui package's ParseColor.
+func testColor(s string) (string, error) {
+	if strings.HasPrefix(s, "#") || strings.Trim(s, "0123456789") == "" || s == "blue" {
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown colour %q", s)
+}
+
+func TestCheckTOML(t *testing.T) {
+	s := newSchema(Options{
+		Modules:    []string{"cpu", "gpu", "memory", "cpu_usage"},
+		Themes:     []string{"default", "nord"},
+		ParseColor: testColor,
+	})
+
+	tests := []struct {
+		file string
+		want []Problem
+	}{
+		{"mistakes.toml", []Problem{
+			{Line: 3, Key: "show_cpu_useage", Msg: `unknown key (did you mean "show_cpu_usage"?)`},
+			{Line: 4, Key: "show_gpu", Msg: `expected true or false, got string "no"`},
+			{Line: 5, Key: "module_timeout_ms", Msg: "expected an integer, got float 1.5"},
+			{Line: 6, Key: "image_mode", Msg: `"sixel" is not one of "ascii", "none"`},
+			{Line: 7, Key: "theme", Msg: `unknown theme "nrod" (did you mean "nord"?)`},
+			{Line: 11, Key: "colors.key", Msg: `unknown colour "blu"`},
+			{Line: 13, Key: "colors.titel", Msg: `unknown key (did you mean "colors.title"?)`},
+			// Keys under an unknown table aren't reported again
+			{Line: 15, Key: "[colours]", Msg: `unknown key (did you mean "colors"?)`},
+		}},
+		{"inline-table.toml", []Problem{
+			{Line: 1, Key: "colors.kye", Msg: `unknown key (did you mean "colors.key"?)`},
+			{Line: 1, Key: "colors.key", Msg: `expected a quoted string, got integer 5 (write "5")`},
+		}},
+		{"bare-word.toml", []Problem{
+			{Line: 3, Key: "image_mode", Msg: `string values must be quoted (write "none")`},
+		}},
+		{"bare-bool.toml", []Problem{
+			{Line: 1, Key: "show_memory", Msg: "expected true or false, got yes"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("testdata", tt.file)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range tt.want {
+				tt.want[i].File = path
+			}
+			if got := checkTOML(path, data, s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkTOML() =\n%s\nwant\n%s", problemLines(got), problemLines(tt.want))
+			}
+		})
+	}
+}
+
+func TestCheckTOMLSyntaxError(t *testing.T) {
+	path := filepath.Join("testdata", "syntax.toml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := checkTOML(path, data, newSchema(Options{}))
+	if len(got) != 1 || got[0].Line != 2 {
+		t.Errorf("checkTOML() =\n%s\nwant one problem on line 2", problemLines(got))
+	}
+}
+
+// The template shipped with pulsefetch must always pass.
+func TestCheckTOMLTemplate(t *testing.T) {
+	path := filepath.Join("..", "pulsefetch.toml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checkTOML(path, data, newSchema(Options{})); len(got) > 0 {
+		t.Errorf("checkTOML() =\n%s\nwant no problems", problemLines(got))
+	}
+}
+
+func problemLines(problems []Problem) string {
+	lines := make([]string, len(problems))
+	for i, p := range problems {
+		lines[i] = "  " + p.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	// Modules holds every show_<name> key found in the config, keyed by
+	// <name>. Use Show to read it with the module's default applied.
+	Modules map[string]bool `mapstructure:"-"`
+
+	// How long to sample network counters for when computing throughput
+	NetworkSampleMs int `mapstructure:"network_sample_ms"`
+
+	// Time limits for collecting a single module and the whole fetch
+	ModuleTimeoutMs int `mapstructure:"module_timeout_ms"`
+	FetchTimeoutMs  int `mapstructure:"fetch_timeout_ms"`
+
+	// Cache slow modules under $XDG_CACHE_HOME/pulsefetch
+	Cache           bool `mapstructure:"cache"`
+	CacheTTLSeconds int  `mapstructure:"cache_ttl_seconds"`
+
+	// Logo: "auto" picks the distro logo from os-release, or a name like
+	// "debian" or "arch_small"
+	Logo     string `mapstructure:"logo"`
+	LogoSize string `mapstructure:"logo_size"` // "full" or "small"
+
+	// Colours: a named theme, then per-element overrides from [colors]
+	Theme  string `mapstructure:"theme"`
+	Accent string `mapstructure:"accent"` // "auto" uses the distro's colour
+	Colors Colors `mapstructure:"colors"`
+
+	// Colour swatches drawn by show_colors: "blocks", "circles" or
+	// "gradient". Each block is PaletteBlock repeated PaletteWidth times and
+	// PaletteHeight lines tall.
+	PaletteStyle  string `mapstructure:"palette_style"`
+	PaletteBlock  string `mapstructure:"palette_block"`
+	PaletteWidth  int    `mapstructure:"palette_width"`
+	PaletteHeight int    `mapstructure:"palette_height"`
+
+	// Image
+	ImagePath string `mapstructure:"image_path"` // Path to custom image
+	ImageMode string `mapstructure:"image_mode"` // "ascii", "none" (maybe "image" later)
+}
+
+// Colors holds colour overrides. Each value is an ANSI 256 index, a hex
+// colour or a colour name; empty means keep the theme's colour.
+type Colors struct {
+	Key       string `mapstructure:"key"`
+	Value     string `mapstructure:"value"`
+	Title     string `mapstructure:"title"`
+	Separator string `mapstructure:"separator"`
+	Logo      string `mapstructure:"logo"`
+}
+
+// Options change where LoadFrom reads the configuration and what it layers
+// on top.
+type Options struct {
+	// Path, if set, is read instead of searching the default locations. It
+	// must exist.
+	Path string
+
+	// Overrides are applied over the file, e.g. from the command line.
+	// Keys use the config names; nested keys are dotted ("colors.key").
+	Overrides map[string]any
+
+	// Modules are the names accepted in show_<name> keys. If nil, any name
+	// is accepted.
+	Modules []string
+
+	// Themes are the names accepted for theme. If nil, any name is
+	// accepted.
+	Themes []string
+
+	// ParseColor validates accent and the [colors] values. If nil, colours
+	// aren't checked.
+	ParseColor func(string) (string, error)
+}
+
+func LoadConfig() (*Config, error) {
+	return LoadFrom(Options{})
+}
+
+// LoadFrom loads the configuration as LoadConfig does, honouring opts.
+func LoadFrom(opts Options) (*Config, error) {
+	viper.SetConfigType("toml") // Treat config as TOML regardless of extension if we force it
+
+	// Defaults; module defaults live with the modules themselves
+	viper.SetDefault("network_sample_ms", 250)
+	viper.SetDefault("module_timeout_ms", 1000)
+	viper.SetDefault("fetch_timeout_ms", 2000)
+	viper.SetDefault("cache", true)
+	viper.SetDefault("cache_ttl_seconds", 3600)
+	viper.SetDefault("theme", "default")
+	viper.SetDefault("accent", "")
+	viper.SetDefault("palette_style", "blocks")
+	viper.SetDefault("palette_block", "█")
+	viper.SetDefault("palette_width", 3)
+	viper.SetDefault("palette_height", 1)
+	viper.SetDefault("logo", "auto")
+	viper.SetDefault("logo_size", "full")
+	viper.SetDefault("image_mode", "ascii")
+
+	// Environment: PULSEFETCH_<KEY> overrides the file, with dots in
+	// nested keys written as underscores (PULSEFETCH_COLORS_KEY). Keys are
+	// bound explicitly so Unmarshal and the show_ scan below see them.
+	viper.SetEnvPrefix(envPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+	for _, key := range Keys() {
+		viper.BindEnv(key)
+	}
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if module, ok := strings.CutPrefix(name, envPrefix+"_SHOW_"); ok {
+			viper.BindEnv("show_" + strings.ToLower(module))
+		}
+	}
+
+	// Reject unknown keys and bad values before viper ignores or
+	// misreads them
+	path, problems, err := Check(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	if path != "" {
+		viper.SetConfigFile(path)
+		if err := viper.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	for key, v := range opts.Overrides {
+		viper.Set(key, v)
+	}
+
+	var cfg Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode into struct: %w", err)
+	}
+
+	cfg.Modules = make(map[string]bool)
+	for _, key := range viper.AllKeys() {
+		if name, ok := strings.CutPrefix(key, "show_"); ok {
+			cfg.Modules[name] = viper.GetBool(key)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// envPrefix starts the environment variables that override config keys.
+const envPrefix = "PULSEFETCH"
+
+// findFile returns the config file to read: --config, then
+// $PULSEFETCH_CONFIG, then the first file found in SearchPaths. It returns
+// "" if there is none.
+func findFile(opts Options) (string, error) {
+	path := opts.Path
+	if path == "" {
+		path = os.Getenv(envPrefix + "_CONFIG")
+	}
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("config file: %w", err)
+		}
+		return path, nil
+	}
+	for _, p := range SearchPaths() {
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", nil
+}
+
+// SearchPaths lists where the config file is looked for, in order:
+// $XDG_CONFIG_HOME (default ~/.config), each of $XDG_CONFIG_DIRS (default
+// /etc/xdg), then /etc/pulsefetch.
+func SearchPaths() []string {
+	var dirs []string
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		dirs = append(dirs, dir)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".config"))
+	}
+
+	system := os.Getenv("XDG_CONFIG_DIRS")
+	if system == "" {
+		system = "/etc/xdg"
+	}
+	for _, dir := range filepath.SplitList(system) {
+		if filepath.IsAbs(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		paths = append(paths, filepath.Join(dir, "pulsefetch", "pulsefetch.toml"))
+	}
+	return append(paths, "/etc/pulsefetch/pulsefetch.toml")
+}
+
+// Keys returns the dotted names of every key in Config, e.g. "theme" and
+// "colors.key". The show_<name> keys aren't included.
+func Keys() []string {
+	return newSchema(Options{}).known()
+}
+
+// Show reports whether show_<name> is enabled, or def if it isn't set.
+func (c *Config) Show(name string, def bool) bool {
+	if v, ok := c.Modules[name]; ok {
+		return v
+	}
+	return def
+}
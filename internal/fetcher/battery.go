@@ -0,0 +1,209 @@
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const powerSupplyDir = "/sys/class/power_supply"
+
+type batteryInfo struct {
+	Name     string
+	Capacity int64  // Charge percent, 0-100
+	Status   string // Charging, Discharging, Full, Not charging, Unknown
+
+	// Health details, zero when the firmware doesn't report them.
+	// Energies are in µWh; batteries reporting charge_* in µAh are
+	// converted using voltage_min_design (or voltage_now).
+	EnergyNow        int64
+	EnergyFull       int64
+	EnergyFullDesign int64
+	PowerNow         int64 // µW, always positive
+	CycleCount       int64
+}
+
+// readBatteries reads every BAT* supply under /sys/class/power_supply.
+func readBatteries(dir string) []batteryInfo {
+	matches, err := filepath.Glob(filepath.Join(dir, "BAT*"))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(matches)
+
+	var bats []batteryInfo
+	for _, path := range matches {
+		if t := readSysString(filepath.Join(path, "type")); t != "" && t != "Battery" {
+			continue
+		}
+		if present, ok := readSysInt(filepath.Join(path, "present")); ok && present == 0 {
+			continue
+		}
+
+		b := batteryInfo{Name: filepath.Base(path)}
+		if c, ok := readSysInt(filepath.Join(path, "capacity")); ok {
+			b.Capacity = c
+		} else if now, full, ok := readSupplyPair(path, "now", "full"); ok && full > 0 {
+			// Some firmware omits capacity, derive it from energy/charge
+			b.Capacity = now * 100 / full
+		} else {
+			continue
+		}
+		b.Status = readSysString(filepath.Join(path, "status"))
+		if b.Status == "" {
+			b.Status = "Unknown"
+		}
+		readBatteryDetails(path, &b)
+		bats = append(bats, b)
+	}
+	return bats
+}
+
+// readSupplyPair reads energy_<a>/energy_<b>, falling back to charge_<a>/charge_<b>.
+func readSupplyPair(path, a, b string) (int64, int64, bool) {
+	for _, prefix := range []string{"energy_", "charge_"} {
+		x, okA := readSysInt(filepath.Join(path, prefix+a))
+		y, okB := readSysInt(filepath.Join(path, prefix+b))
+		if okA && okB {
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
+
+func readBatteryDetails(path string, b *batteryInfo) {
+	if c, ok := readSysInt(filepath.Join(path, "cycle_count")); ok && c > 0 {
+		b.CycleCount = c
+	}
+
+	if now, ok := readSysInt(filepath.Join(path, "energy_now")); ok {
+		b.EnergyNow = now
+		b.EnergyFull, _ = readSysInt(filepath.Join(path, "energy_full"))
+		b.EnergyFullDesign, _ = readSysInt(filepath.Join(path, "energy_full_design"))
+		if p, ok := readSysInt(filepath.Join(path, "power_now")); ok {
+			b.PowerNow = abs64(p)
+		}
+		return
+	}
+
+	// charge_* batteries report µAh and µA, scale by voltage to get µWh/µW
+	now, ok := readSysInt(filepath.Join(path, "charge_now"))
+	if !ok {
+		return
+	}
+	volts, ok := readSysInt(filepath.Join(path, "voltage_min_design"))
+	if !ok {
+		volts, ok = readSysInt(filepath.Join(path, "voltage_now"))
+	}
+	if !ok || volts <= 0 {
+		return
+	}
+	toEnergy := func(v int64) int64 { return v * volts / 1000000 }
+
+	b.EnergyNow = toEnergy(now)
+	if full, ok := readSysInt(filepath.Join(path, "charge_full")); ok {
+		b.EnergyFull = toEnergy(full)
+	}
+	if design, ok := readSysInt(filepath.Join(path, "charge_full_design")); ok {
+		b.EnergyFullDesign = toEnergy(design)
+	}
+	if cur, ok := readSysInt(filepath.Join(path, "current_now")); ok {
+		if v, ok := readSysInt(filepath.Join(path, "voltage_now")); ok {
+			b.PowerNow = abs64(cur) * v / 1000000
+		}
+	}
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// wearPercent returns how much of the design capacity has been lost.
+func (b batteryInfo) wearPercent() (float64, bool) {
+	if b.EnergyFullDesign <= 0 || b.EnergyFull <= 0 {
+		return 0, false
+	}
+	wear := 100 - float64(b.EnergyFull)*100/float64(b.EnergyFullDesign)
+	if wear < 0 {
+		wear = 0
+	}
+	return wear, true
+}
+
+// timeRemaining estimates the time to empty when discharging, or to full
+// when charging, from the current power draw.
+func (b batteryInfo) timeRemaining() (time.Duration, bool) {
+	if b.PowerNow <= 0 {
+		return 0, false
+	}
+	var energy int64
+	switch b.Status {
+	case "Discharging":
+		energy = b.EnergyNow
+	case "Charging":
+		energy = b.EnergyFull - b.EnergyNow
+	default:
+		return 0, false
+	}
+	if energy <= 0 {
+		return 0, false
+	}
+	hours := float64(energy) / float64(b.PowerNow)
+	return time.Duration(hours * float64(time.Hour)), true
+}
+
+// acOnline reports whether any mains supply is online. The second value is
+// false when no AC adapter is exposed at all (desktops, some VMs).
+func acOnline(dir string) (bool, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, false
+	}
+	found := false
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if readSysString(filepath.Join(path, "type")) != "Mains" {
+			continue
+		}
+		found = true
+		if v, ok := readSysInt(filepath.Join(path, "online")); ok && v == 1 {
+			return true, true
+		}
+	}
+	return false, found
+}
+
+// getBattery reads every battery along with its health details, and the
+// AC adapter state.
+func getBattery() Battery {
+	var b Battery
+	for _, info := range readBatteries(powerSupplyDir) {
+		st := BatteryStatus{
+			Name:               info.Name,
+			Percent:            int(info.Capacity),
+			Status:             info.Status,
+			PowerW:             float64(info.PowerNow) / 1e6,
+			EnergyFullWh:       float64(info.EnergyFull) / 1e6,
+			EnergyFullDesignWh: float64(info.EnergyFullDesign) / 1e6,
+			Cycles:             int(info.CycleCount),
+		}
+		if d, ok := info.timeRemaining(); ok {
+			st.RemainingSeconds = int64(d / time.Second)
+		}
+		if wear, ok := info.wearPercent(); ok {
+			st.WearPercent = wear
+		}
+		b.Batteries = append(b.Batteries, st)
+	}
+	if len(b.Batteries) == 0 {
+		return b
+	}
+	if online, ok := acOnline(powerSupplyDir); ok {
+		b.ACOnline = &online
+	}
+	return b
+}
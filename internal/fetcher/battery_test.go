@@ -0,0 +1,121 @@
+package fetcher
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadBatteries(t *testing.T) {
+	type bat struct {
+		Name     string
+		Capacity int64
+		Status   string
+	}
+	tests := []struct {
+		dir  string
+		want []bat
+	}{
+		// BAT1 has no capacity file, BAT2 isn't present and BAT3 is a UPS
+		{"laptop", []bat{{"BAT0", 80, "Discharging"}, {"BAT1", 50, "Charging"}}},
+		{"unplugged", []bat{{"BAT0", 15, "Unknown"}}},
+		// Peripheral batteries aren't BAT*
+		{"desktop", nil},
+		{"missing", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			var got []bat
+			for _, b := range readBatteries(filepath.Join("testdata", "power_supply", tt.dir)) {
+				got = append(got, bat{b.Name, b.Capacity, b.Status})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("readBatteries() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("battery %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestACOnline(t *testing.T) {
+	tests := []struct {
+		dir                string
+		wantOnline, wantOK bool
+	}{
+		{"laptop", true, true},
+		// A USB-C source being online doesn't count as mains
+		{"unplugged", false, true},
+		{"desktop", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			online, ok := acOnline(filepath.Join("testdata", "power_supply", tt.dir))
+			if online != tt.wantOnline || ok != tt.wantOK {
+				t.Errorf("acOnline() = %v, %v, want %v, %v", online, ok, tt.wantOnline, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBatteryDetails(t *testing.T) {
+	bats := readBatteries(filepath.Join("testdata", "power_supply", "laptop"))
+	if len(bats) != 2 {
+		t.Fatalf("readBatteries() found %d batteries, want 2", len(bats))
+	}
+
+	tests := []struct {
+		name          string
+		got           batteryInfo
+		want          batteryInfo
+		wantWear      float64
+		wantRemaining string
+	}{
+		{
+			name: "energy",
+			got:  bats[0],
+			want: batteryInfo{Name: "BAT0", Capacity: 80, Status: "Discharging",
+				EnergyNow: 40000000, EnergyFull: 45000000, EnergyFullDesign: 50000000, PowerNow: 10000000, CycleCount: 300},
+			wantWear:      10,
+			wantRemaining: "4h0m0s",
+		},
+		{
+			// µAh scaled by voltage_min_design, µA by voltage_now; a
+			// zero cycle_count means unknown
+			name: "charge",
+			got:  bats[1],
+			want: batteryInfo{Name: "BAT1", Capacity: 50, Status: "Charging",
+				EnergyNow: 22200000, EnergyFull: 44400000, EnergyFullDesign: 55500000, PowerNow: 18000000},
+			wantWear:      20,
+			wantRemaining: "1h14m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("battery = %+v, want %+v", tt.got, tt.want)
+			}
+			if wear, ok := tt.got.wearPercent(); !ok || math.Abs(wear-tt.wantWear) > 0.01 {
+				t.Errorf("wearPercent() = %v, %v, want %v", wear, ok, tt.wantWear)
+			}
+			if d, ok := tt.got.timeRemaining(); !ok || d.Round(time.Minute).String() != tt.wantRemaining {
+				t.Errorf("timeRemaining() = %v, %v, want %s", d, ok, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestBatteryDetailsMissing(t *testing.T) {
+	// Only a capacity: no wear or time remaining to report
+	b := readBatteries(filepath.Join("testdata", "power_supply", "unplugged"))[0]
+	if _, ok := b.wearPercent(); ok {
+		t.Error("wearPercent() reported a value without energy_full")
+	}
+	if _, ok := b.timeRemaining(); ok {
+		t.Error("timeRemaining() reported a value without power_now")
+	}
+}
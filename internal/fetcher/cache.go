@@ -0,0 +1,188 @@
+package fetcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Results of slow modules are cached under $XDG_CACHE_HOME/pulsefetch, one
+// JSON file per module. An entry is reused until it's older than the TTL
+// or a stamp of one of the module's source paths has changed.
+
+type cacheEntry struct {
+	Written time.Time         `json:"written"`
+	Sources map[string]string `json:"sources"`
+	Kind    string            `json:"kind"`
+	Value   json.RawMessage   `json:"value"`
+}
+
+// valueKind names the type of a cacheable module value so it can be
+// decoded again; "" means the value can't be cached.
+func valueKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "none"
+	case string:
+		return "string"
+	case []string:
+		return "lines"
+	case []PackageCount:
+		return "packages"
+	}
+	return ""
+}
+
+func decodeValue(kind string, raw json.RawMessage) (any, error) {
+	switch kind {
+	case "none":
+		return nil, nil
+	case "string":
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case "lines":
+		var lines []string
+		err := json.Unmarshal(raw, &lines)
+		return lines, err
+	case "packages":
+		var counts []PackageCount
+		err := json.Unmarshal(raw, &counts)
+		return counts, err
+	}
+	return nil, fmt.Errorf("unknown cache value kind %q", kind)
+}
+
+func cacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pulsefetch")
+}
+
+// sourceStamp summarises a path's state. Symlinks are stamped by their
+// target (Nix profiles switch generations by relinking), /proc and /sys by
+// a hash of their contents since their mtimes are meaningless, and
+// everything else by mtime and size.
+func sourceStamp(path string) string {
+	st, err := os.Lstat(path)
+	if err != nil {
+		return "absent"
+	}
+	if st.Mode()&os.ModeSymlink != 0 {
+		target, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return "dangling"
+		}
+		return "link:" + target
+	}
+	if strings.HasPrefix(path, "/proc/") || strings.HasPrefix(path, "/sys/") {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "unreadable"
+		}
+		sum := sha256.Sum256(data)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return strconv.FormatInt(st.ModTime().UnixNano(), 10) + ":" + strconv.FormatInt(st.Size(), 10)
+}
+
+func stampSources(paths []string) map[string]string {
+	stamps := make(map[string]string, len(paths))
+	for _, p := range paths {
+		if p != "" {
+			stamps[p] = sourceStamp(p)
+		}
+	}
+	return stamps
+}
+
+func cachePath(module string) string {
+	dir := cacheDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, module+".json")
+}
+
+// loadCached returns the cached value for module if the entry is still
+// valid for the given sources.
+func loadCached(module string, sources []string, ttl time.Duration) (any, bool) {
+	path := cachePath(module)
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+	if ttl > 0 && time.Since(e.Written) > ttl {
+		return nil, false
+	}
+
+	current := stampSources(sources)
+	if len(current) != len(e.Sources) {
+		return nil, false
+	}
+	for p, stamp := range current {
+		if e.Sources[p] != stamp {
+			return nil, false
+		}
+	}
+	v, err := decodeValue(e.Kind, e.Value)
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// storeCached writes a module result. Failures are ignored; the cache is
+// only an optimisation.
+func storeCached(module string, sources []string, v any) {
+	path := cachePath(module)
+	kind := valueKind(v)
+	if path == "" || kind == "" {
+		return
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(cacheEntry{
+		Written: time.Now(),
+		Sources: stampSources(sources),
+		Kind:    kind,
+		Value:   raw,
+	})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	// Write then rename so concurrent shells never read a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(path), module+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
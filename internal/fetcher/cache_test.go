@@ -0,0 +1,126 @@
+package fetcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"pulsefetch/config"
+	"pulsefetch/module"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	src := filepath.Join(t.TempDir(), "status")
+	if err := os.WriteFile(src, []byte("installed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sources := []string{src, filepath.Join(t.TempDir(), "absent")}
+
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"string", "Intel Iris Xe"},
+		{"lines", []string{"GPU one", "GPU two"}},
+		{"packages", []PackageCount{{"dpkg", 541}, {"flatpak", 3}}},
+		{"none", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storeCached(tt.name, sources, tt.v)
+			got, ok := loadCached(tt.name, sources, time.Hour)
+			if !ok {
+				t.Fatal("loadCached() missed a fresh entry")
+			}
+			if !reflect.DeepEqual(got, tt.v) {
+				t.Errorf("loadCached() = %#v, want %#v", got, tt.v)
+			}
+		})
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		ttl    time.Duration
+		change func(t *testing.T, src, other string) []string
+	}{
+		{"source modified", time.Hour, func(t *testing.T, src, other string) []string {
+			later := time.Now().Add(time.Minute)
+			if err := os.Chtimes(src, later, later); err != nil {
+				t.Fatal(err)
+			}
+			return []string{src}
+		}},
+		{"source removed", time.Hour, func(t *testing.T, src, other string) []string {
+			if err := os.Remove(src); err != nil {
+				t.Fatal(err)
+			}
+			return []string{src}
+		}},
+		{"different sources", time.Hour, func(t *testing.T, src, other string) []string {
+			return []string{src, other}
+		}},
+		{"expired", time.Nanosecond, func(t *testing.T, src, other string) []string {
+			time.Sleep(time.Millisecond)
+			return []string{src}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			dir := t.TempDir()
+			src, other := filepath.Join(dir, "src"), filepath.Join(dir, "other")
+			if err := os.WriteFile(src, []byte("1"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			storeCached("packages", []string{src}, "cached")
+			sources := tt.change(t, src, other)
+			if v, ok := loadCached("packages", sources, tt.ttl); ok {
+				t.Errorf("loadCached() = %#v, want a miss", v)
+			}
+		})
+	}
+}
+
+func TestCacheSkipsUncacheable(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+
+	storeCached("memory", nil, Usage{Used: 1, Total: 2})
+	if _, err := os.Stat(filepath.Join(cache, "pulsefetch", "memory.json")); !os.IsNotExist(err) {
+		t.Errorf("storeCached() wrote a value it can't decode (stat error %v)", err)
+	}
+}
+
+func TestRunModulesCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	src := filepath.Join(t.TempDir(), "db")
+	if err := os.WriteFile(src, []byte("1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Cache: true, CacheTTLSeconds: 3600}
+	sources := func(cfg *config.Config) []string { return []string{src} }
+
+	// A result cut short by the timeout must not be cached
+	slow := module.Module{Name: "gpu", Collect: sleeper(time.Second, "partial"), Sources: sources}
+	if got := runModules(context.Background(), cfg, []module.Module{slow}, 20*time.Millisecond); got[0] != (timedOut{}) {
+		t.Fatalf("slow module = %#v, want timedOut{}", got[0])
+	}
+	if v, ok := loadCached("gpu", []string{src}, time.Hour); ok {
+		t.Fatalf("timed-out result was cached: %#v", v)
+	}
+
+	// A complete one is, and is served on the next run
+	fast := module.Module{Name: "gpu", Collect: sleeper(0, "complete"), Sources: sources}
+	runModules(context.Background(), cfg, []module.Module{fast}, time.Second)
+	fast.Collect = sleeper(0, "recomputed")
+	if got := runModules(context.Background(), cfg, []module.Module{fast}, time.Second); got[0] != "complete" {
+		t.Errorf("second run = %#v, want the cached %q", got[0], "complete")
+	}
+}
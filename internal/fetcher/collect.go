@@ -0,0 +1,73 @@
+package fetcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"pulsefetch/config"
+	"pulsefetch/module"
+)
+
+// timedOut is the result of a module that missed its deadline.
+type timedOut struct{}
+
+// runModules collects every module concurrently, each bounded by timeout (or
+// its own longer Timeout) and all of them by ctx. Cacheable modules are served from the cache when it's
+// enabled and still valid. Results are returned in module order.
+func runModules(ctx context.Context, cfg *config.Config, modules []module.Module, timeout time.Duration) []any {
+	results := make([]any, len(modules))
+	ttl := time.Duration(cfg.CacheTTLSeconds) * time.Second
+
+	var wg sync.WaitGroup
+	for i, m := range modules {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			mctx := ctx
+			if timeout > 0 {
+				mt := timeout
+				if m.Timeout != nil {
+					mt = max(mt, m.Timeout(cfg))
+				}
+				var cancel context.CancelFunc
+				mctx, cancel = context.WithTimeout(ctx, mt)
+				defer cancel()
+			}
+
+			var sources []string
+			if cfg.Cache && m.Sources != nil {
+				sources = m.Sources(cfg)
+				if v, ok := loadCached(m.Name, sources, ttl); ok {
+					results[i] = v
+					return
+				}
+			}
+
+			// Buffered so a module that finishes after its deadline can
+			// still send and exit
+			done := make(chan any, 1)
+			go func() { done <- m.Collect(mctx, cfg) }()
+
+			select {
+			case v := <-done:
+				// A module that returns because its context was cancelled
+				// may have been cut short, so neither show nor cache it
+				if mctx.Err() != nil {
+					results[i] = timedOut{}
+					return
+				}
+				results[i] = v
+				if sources != nil {
+					storeCached(m.Name, sources, v)
+				}
+			case <-mctx.Done():
+				results[i] = timedOut{}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
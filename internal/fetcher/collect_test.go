@@ -0,0 +1,58 @@
+package fetcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pulsefetch/config"
+	"pulsefetch/module"
+)
+
+// sleeper returns a Collect func that waits for d, or until ctx is done.
+func sleeper(d time.Duration, v any) func(context.Context, *config.Config) any {
+	return func(ctx context.Context, cfg *config.Config) any {
+		select {
+		case <-time.After(d):
+			return v
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func TestRunModules(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	long := func(cfg *config.Config) time.Duration { return 300 * time.Millisecond }
+
+	modules := []module.Module{
+		{Name: "fast", Collect: sleeper(0, "fast")},
+		// Needs longer than timeout, and says so
+		{Name: "sampler", Collect: sleeper(150*time.Millisecond, "sampled"), Timeout: long},
+		// Started alongside sampler, but must not inherit its timeout
+		{Name: "slow", Collect: sleeper(150*time.Millisecond, "slow")},
+		{Name: "slow2", Collect: sleeper(150*time.Millisecond, "slow")},
+	}
+	want := []any{"fast", "sampled", timedOut{}, timedOut{}}
+
+	got := runModules(context.Background(), &config.Config{}, modules, timeout)
+	if len(got) != len(want) {
+		t.Fatalf("runModules() returned %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %#v, want %#v", modules[i].Name, got[i], want[i])
+		}
+	}
+}
+
+func TestRunModulesCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	modules := []module.Module{{Name: "slow", Collect: sleeper(time.Second, "slow")}}
+	got := runModules(ctx, &config.Config{}, modules, time.Second)
+	if got[0] != (timedOut{}) {
+		t.Errorf("slow = %#v, want timedOut{}", got[0])
+	}
+}
@@ -0,0 +1,211 @@
+package fetcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// devPackageCounters count globally installed language tooling. Each reads
+// the manager's own manifest or install directory instead of running it.
+var devPackageCounters = []packageCounter{
+	{"cargo", countCargo, func() []string { return []string{filepath.Join(envOrHome("CARGO_HOME", ".cargo"), ".crates2.json")} }},
+	{"pipx", countPipx, pipxSources},
+	{"uv", countUvTools, func() []string { return []string{uvToolDir()} }},
+	{"npm", countNpmGlobal, npmSources},
+	{"go", countGoBin, func() []string { return []string{goBinDir()} }},
+	{"gem", countGems, gemSources},
+}
+
+// homePath joins rel onto the user's home directory.
+func homePath(rel ...string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(append([]string{home}, rel...)...)
+}
+
+// envOrHome returns $env if set, otherwise ~/<rel...>.
+func envOrHome(env string, rel ...string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return homePath(rel...)
+}
+
+func dataHome() string {
+	return envOrHome("XDG_DATA_HOME", ".local", "share")
+}
+
+// countCargo counts the entries of the "installs" map in .crates2.json.
+func countCargo(ctx context.Context) int {
+	data, err := os.ReadFile(filepath.Join(envOrHome("CARGO_HOME", ".cargo"), ".crates2.json"))
+	if err != nil {
+		return 0
+	}
+	var crates struct {
+		Installs map[string]json.RawMessage `json:"installs"`
+	}
+	if err := json.Unmarshal(data, &crates); err != nil {
+		return 0
+	}
+	return len(crates.Installs)
+}
+
+func pipxVenvDirs() []string {
+	if home := os.Getenv("PIPX_HOME"); home != "" {
+		return []string{filepath.Join(home, "venvs")}
+	}
+	// pipx before 1.3 kept its venvs in ~/.local/pipx
+	return []string{filepath.Join(dataHome(), "pipx", "venvs"), homePath(".local", "pipx", "venvs")}
+}
+
+func pipxSources() []string {
+	return pipxVenvDirs()
+}
+
+// countPipx counts the per-application virtualenvs.
+func countPipx(ctx context.Context) int {
+	for _, dir := range pipxVenvDirs() {
+		if n := countDirs(dir); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+func uvToolDir() string {
+	if dir := os.Getenv("UV_TOOL_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(dataHome(), "uv", "tools")
+}
+
+// countUvTools counts the environments created by `uv tool install`.
+func countUvTools(ctx context.Context) int {
+	return countDirs(uvToolDir())
+}
+
+// npmPrefixes finds the global install prefix the same way npm does:
+// $NPM_CONFIG_PREFIX, then prefix= in ~/.npmrc, then the usual defaults.
+func npmPrefixes() []string {
+	if p := os.Getenv("NPM_CONFIG_PREFIX"); p != "" {
+		return []string{p}
+	}
+	if f, err := os.Open(homePath(".npmrc")); err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			k, v, ok := strings.Cut(scanner.Text(), "=")
+			if ok && strings.TrimSpace(k) == "prefix" {
+				return []string{os.ExpandEnv(strings.TrimSpace(v))}
+			}
+		}
+	}
+	return []string{"/usr/local", "/usr"}
+}
+
+func npmSources() []string {
+	sources := []string{homePath(".npmrc")}
+	for _, prefix := range npmPrefixes() {
+		sources = append(sources, filepath.Join(prefix, "lib", "node_modules"))
+	}
+	return sources
+}
+
+// countNpmGlobal counts packages in the global node_modules, including the
+// packages inside @scope directories.
+func countNpmGlobal(ctx context.Context) int {
+	for _, prefix := range npmPrefixes() {
+		entries, err := os.ReadDir(filepath.Join(prefix, "lib", "node_modules"))
+		if err != nil {
+			continue
+		}
+		n := 0
+		for _, e := range entries {
+			name := e.Name()
+			if strings.HasPrefix(name, ".") {
+				continue
+			}
+			if strings.HasPrefix(name, "@") {
+				n += countDirs(filepath.Join(prefix, "lib", "node_modules", name))
+				continue
+			}
+			n++
+		}
+		if n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// goBinDir is $GOBIN or the first $GOPATH's bin, where go install writes.
+func goBinDir() string {
+	if dir := os.Getenv("GOBIN"); dir != "" {
+		return dir
+	}
+	gopath := os.Getenv("GOPATH")
+	if gopath != "" {
+		gopath = filepath.SplitList(gopath)[0]
+	} else {
+		gopath = homePath("go")
+	}
+	return filepath.Join(gopath, "bin")
+}
+
+// countGoBin counts the executables in goBinDir.
+func countGoBin(ctx context.Context) int {
+	entries, err := os.ReadDir(goBinDir())
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		info, err := e.Info()
+		if err == nil && info.Mode().IsRegular() && info.Mode()&0o111 != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func gemSpecPatterns() []string {
+	var patterns []string
+	if home := os.Getenv("GEM_HOME"); home != "" {
+		patterns = append(patterns, filepath.Join(home, "specifications", "*.gemspec"))
+	}
+	return append(patterns,
+		filepath.Join(homePath(".gem"), "ruby", "*", "specifications", "*.gemspec"),
+		filepath.Join(dataHome(), "gem", "ruby", "*", "specifications", "*.gemspec"),
+		"/usr/local/lib/ruby/gems/*/specifications/*.gemspec",
+		"/var/lib/gems/*/specifications/*.gemspec",
+	)
+}
+
+// gemSources are the existing specifications directories.
+func gemSources() []string {
+	var sources []string
+	for _, pattern := range gemSpecPatterns() {
+		dirs, _ := filepath.Glob(filepath.Dir(pattern))
+		sources = append(sources, dirs...)
+	}
+	return sources
+}
+
+// countGems counts the gemspecs in $GEM_HOME, the per-user gem directories
+// and the default locations `gem install` writes to as root.
+func countGems(ctx context.Context) int {
+	seen := make(map[string]bool)
+	for _, pattern := range gemSpecPatterns() {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			seen[m] = true
+		}
+	}
+	return len(seen)
+}
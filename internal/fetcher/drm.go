@@ -0,0 +1,142 @@
+package fetcher
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const drmDir = "/sys/class/drm"
+
+// displayOutput describes one connected monitor as seen through DRM sysfs.
+type displayOutput struct {
+	Connector string // e.g. HDMI-A-1, eDP-1
+	Name      string // Monitor name from EDID, may be empty
+	Width     int
+	Height    int
+	RefreshHz float64 // From the EDID preferred timing, 0 if unknown
+	WidthMM   int
+	HeightMM  int
+}
+
+// edidInfo holds the fields we decode from a base EDID block.
+type edidInfo struct {
+	Manufacturer string
+	Name         string
+	WidthMM      int
+	HeightMM     int
+	Width        int // Preferred mode
+	Height       int
+	RefreshHz    float64
+}
+
+var edidHeader = []byte{0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}
+
+// parseEDID decodes the monitor name, physical size and preferred detailed
+// timing from a 128-byte EDID base block.
+func parseEDID(data []byte) (edidInfo, error) {
+	var e edidInfo
+	if len(data) < 128 || !bytes.Equal(data[:8], edidHeader) {
+		return e, fmt.Errorf("invalid EDID")
+	}
+
+	// Three 5-bit letters packed big-endian, 'A' == 1
+	id := uint16(data[8])<<8 | uint16(data[9])
+	e.Manufacturer = string([]byte{
+		byte('A' - 1 + (id>>10)&0x1f),
+		byte('A' - 1 + (id>>5)&0x1f),
+		byte('A' - 1 + id&0x1f),
+	})
+
+	// Screen size in centimetres, refined below by the DTD's millimetres
+	e.WidthMM = int(data[21]) * 10
+	e.HeightMM = int(data[22]) * 10
+
+	for i := 0; i < 4; i++ {
+		d := data[54+i*18 : 54+(i+1)*18]
+		clock := int(d[0]) | int(d[1])<<8
+		if clock != 0 {
+			if e.Width != 0 {
+				continue // Only the first DTD is the preferred mode
+			}
+			hActive := int(d[2]) | int(d[4]&0xf0)<<4
+			hBlank := int(d[3]) | int(d[4]&0x0f)<<8
+			vActive := int(d[5]) | int(d[7]&0xf0)<<4
+			vBlank := int(d[6]) | int(d[7]&0x0f)<<8
+			e.Width, e.Height = hActive, vActive
+			if total := (hActive + hBlank) * (vActive + vBlank); total > 0 {
+				e.RefreshHz = float64(clock) * 10000 / float64(total)
+			}
+			if w, h := int(d[12])|int(d[14]&0xf0)<<4, int(d[13])|int(d[14]&0x0f)<<8; w > 0 && h > 0 {
+				e.WidthMM, e.HeightMM = w, h
+			}
+			continue
+		}
+		// Display descriptor; 0xfc is the monitor name
+		if d[3] == 0xfc {
+			name := d[5:18]
+			if j := bytes.IndexByte(name, '\n'); j >= 0 {
+				name = name[:j]
+			}
+			e.Name = strings.TrimSpace(string(name))
+		}
+	}
+	return e, nil
+}
+
+// readDRMOutputs returns every connected connector under /sys/class/drm.
+// The resolution comes from the first entry in modes (the preferred mode)
+// and falls back to the EDID's preferred timing.
+func readDRMOutputs(dir string) []displayOutput {
+	connectors, _ := filepath.Glob(filepath.Join(dir, "card*-*"))
+	sort.Strings(connectors)
+
+	var outputs []displayOutput
+	for _, path := range connectors {
+		if readSysString(filepath.Join(path, "status")) != "connected" {
+			continue
+		}
+
+		base := filepath.Base(path)
+		out := displayOutput{Connector: base[strings.Index(base, "-")+1:]}
+
+		if raw, err := os.ReadFile(filepath.Join(path, "edid")); err == nil {
+			if e, err := parseEDID(raw); err == nil {
+				out.Name = e.Name
+				out.Width, out.Height = e.Width, e.Height
+				out.RefreshHz = e.RefreshHz
+				out.WidthMM, out.HeightMM = e.WidthMM, e.HeightMM
+			}
+		}
+
+		modes := readSysString(filepath.Join(path, "modes"))
+		if first, _, _ := strings.Cut(modes, "\n"); first != "" {
+			var w, h int
+			if _, err := fmt.Sscanf(first, "%dx%d", &w, &h); err == nil {
+				if w != out.Width || h != out.Height {
+					// The EDID refresh rate belongs to a different mode
+					out.RefreshHz = 0
+				}
+				out.Width, out.Height = w, h
+			}
+		}
+
+		if out.Width == 0 || out.Height == 0 {
+			continue
+		}
+		outputs = append(outputs, out)
+	}
+	return outputs
+}
+
+// diagonalInches returns the screen diagonal, or 0 if the EDID had no size.
+func (o displayOutput) diagonalInches() float64 {
+	if o.WidthMM <= 0 || o.HeightMM <= 0 {
+		return 0
+	}
+	return math.Hypot(float64(o.WidthMM), float64(o.HeightMM)) / 25.4
+}
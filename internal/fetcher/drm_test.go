@@ -0,0 +1,54 @@
+package fetcher
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseEDID(t *testing.T) {
+	tests := []struct {
+		file    string
+		want    edidInfo
+		wantErr bool
+	}{
+		{
+			file: "dell-u2415.bin",
+			want: edidInfo{Manufacturer: "DEL", Name: "DELL U2415", WidthMM: 518, HeightMM: 324, Width: 1920, Height: 1200, RefreshHz: 59.95},
+		},
+		{
+			// No name descriptor and no size in the DTD, so the size
+			// comes from the centimetre fields
+			file: "noname-1440p.bin",
+			want: edidInfo{Manufacturer: "GSM", WidthMM: 600, HeightMM: 340, Width: 2560, Height: 1440, RefreshHz: 59.95},
+		},
+		{file: "truncated.bin", wantErr: true},
+		{file: "bad-header.bin", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "edid", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := parseEDID(data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseEDID() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEDID() error: %v", err)
+			}
+			if math.Abs(got.RefreshHz-tt.want.RefreshHz) > 0.01 {
+				t.Errorf("RefreshHz = %v, want %v", got.RefreshHz, tt.want.RefreshHz)
+			}
+			got.RefreshHz = tt.want.RefreshHz
+			if got != tt.want {
+				t.Errorf("parseEDID() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,203 +1,136 @@
 package fetcher
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/user"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
-	"pulsefetch/internal/config"
+	"pulsefetch/config"
+	"pulsefetch/module"
 
-	//"github.com/shirou/gopsutil/v3/battery"
-	"github.com/shirou/gopsutil/v3/cpu"
-	"github.com/shirou/gopsutil/v3/disk"
-	"github.com/shirou/gopsutil/v3/host"
-	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/shirou/gopsutil/v3/net"
 )
 
 type SystemInfo struct {
-	User         string
-	OS           string
-	Hostname     string
-	Host         string // Hardware Model
-	Kernel       string
-	Uptime       string
-	Packages     string
-	Shell        string
-	Resolution   string
-	DE           string
-	WM           string
-	WMTheme      string
-	Theme        string
-	Icons        string
-	Terminal     string
-	CPU          string
-	GPUs         []string
-	Memory       string
-	Disk         string
-	Network      string 
-	Battery      string
-	Sensors      string
-	
-	CPUUsage     string
-	MemoryUsage  string
-	DiskUsage    string
+	User     string    `json:"user,omitempty"`
+	Hostname string    `json:"hostname,omitempty"`
+	OS       OSRelease `json:"os_release"`
+
+	// Values holds the value collected by each enabled module, keyed by
+	// module name: a string, a []string for multi-line modules, or a typed
+	// value such as Usage, Percent, time.Duration or []PackageCount.
+	// Modules with nothing to show are absent.
+	Values map[string]any `json:"modules"`
+
+	// TimedOut lists the enabled modules, in display order, that didn't
+	// finish in time. They have no entry in Values.
+	TimedOut []string `json:"timed_out,omitempty"`
 }
 
-func Fetch(cfg *config.Config) (*SystemInfo, error) {
-	info := &SystemInfo{}
-
-	u, err := user.Current()
-	if err == nil {
-		info.User = u.Username
-	}
+// IsTimedOut reports whether the named module missed its deadline.
+func (s *SystemInfo) IsTimedOut(name string) bool {
+	return slices.Contains(s.TimedOut, name)
+}
 
-	h, err := host.Info()
-	if err == nil {
-		info.Hostname = h.Hostname
-		if cfg.ShowOS {
-			info.OS = fmt.Sprintf("%s %s", h.Platform, h.PlatformVersion)
-		}
-		if cfg.ShowKernel {
-			info.Kernel = h.KernelVersion
-		}
-		if cfg.ShowUptime {
-			d := time.Duration(h.Uptime) * time.Second
-			info.Uptime = formatDuration(d)
+// MarshalJSON writes the module values as they are, except durations,
+// which are written in whole seconds.
+func (s SystemInfo) MarshalJSON() ([]byte, error) {
+	type plain SystemInfo
+	out := plain(s)
+	out.Values = make(map[string]any, len(s.Values))
+	for name, v := range s.Values {
+		if d, ok := v.(time.Duration); ok {
+			v = int64(d / time.Second)
 		}
+		out.Values[name] = v
 	}
+	return json.Marshal(out)
+}
 
-	if cfg.ShowHost {
-		info.Host = getModel()
-	}
-
-	if cfg.ShowCPU || cfg.ShowCPUUsage {
-		c, err := cpu.Info()
-		if err == nil && len(c) > 0 {
-			info.CPU = c[0].ModelName
-		}
-		if cfg.ShowCPUUsage {
-			percent, err := cpu.Percent(0, false)
-			if err == nil && len(percent) > 0 {
-				info.CPUUsage = fmt.Sprintf("%.1f%%", percent[0])
-			}
+// Get returns the named module's value if it's a string, or the first line
+// of a multi-line module, or "".
+func (s *SystemInfo) Get(name string) string {
+	switch v := s.Values[name].(type) {
+	case string:
+		return v
+	case []string:
+		if len(v) > 0 {
+			return v[0]
 		}
 	}
+	return ""
+}
 
-	if cfg.ShowGPU {
-		info.GPUs = getGPU()
+func Fetch(cfg *config.Config) (*SystemInfo, error) {
+	ctx := context.Background()
+	if cfg.FetchTimeoutMs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.FetchTimeoutMs)*time.Millisecond)
+		defer cancel()
 	}
+	return FetchContext(ctx, cfg)
+}
 
-	if cfg.ShowResolution {
-		info.Resolution = getResolution()
-	}
+// FetchContext collects every enabled module concurrently. Each module gets
+// cfg.ModuleTimeoutMs to finish; modules that don't are shown as timed out
+// and the rest of the output is unaffected.
+func FetchContext(ctx context.Context, cfg *config.Config) (*SystemInfo, error) {
+	info := &SystemInfo{Values: make(map[string]any)}
 
-	if cfg.ShowMemory || cfg.ShowMemoryUsage {
-		v, err := mem.VirtualMemory()
-		if err == nil {
-			if cfg.ShowMemory {
-				info.Memory = fmt.Sprintf("%vMiB / %vMiB", v.Used/1024/1024, v.Total/1024/1024)
-			}
-			if cfg.ShowMemoryUsage {
-				info.MemoryUsage = fmt.Sprintf("%.1f%%", v.UsedPercent)
-			}
-		}
+	if u, err := user.Current(); err == nil {
+		info.User = u.Username
 	}
-
-	if cfg.ShowDisk || cfg.ShowDiskUsage {
-		parts, err := disk.Partitions(false)
-		if err == nil && len(parts) > 0 {
-			u, err := disk.Usage("/")
-			if err == nil {
-				if cfg.ShowDisk {
-					info.Disk = fmt.Sprintf("%vGiB / %vGiB", u.Used/1024/1024/1024, u.Total/1024/1024/1024)
-				}
-				if cfg.ShowDiskUsage {
-					info.DiskUsage = fmt.Sprintf("%.1f%%", u.UsedPercent)
-				}
-			}
-		}
+	if h, err := os.Hostname(); err == nil {
+		info.Hostname = h
 	}
-	
-	if cfg.ShowNetwork {
-		ifaces, err := net.Interfaces()
-		if err == nil {
-			for _, i := range ifaces {
-				isLoopback := false
-				for _, flag := range i.Flags {
-					if flag == "loopback" {
-						isLoopback = true
-						break
-					}
-				}
-				if isLoopback { continue }
-				for _, addr := range i.Addrs {
-					if strings.Contains(addr.Addr, ".") {
-						info.Network = addr.Addr
-						break
-					}
-				}
-				if info.Network != "" { break }
-			}
+	info.OS = currentOSRelease()
+
+	var enabled []module.Module
+	for _, m := range module.Modules() {
+		if m.Collect != nil && m.Enabled(cfg) {
+			enabled = append(enabled, m)
 		}
 	}
 
-	if cfg.ShowShell {
-		info.Shell = os.Getenv("SHELL")
-		if info.Shell != "" {
-			parts := strings.Split(info.Shell, "/")
-			info.Shell = parts[len(parts)-1]
+	results := runModules(ctx, cfg, enabled, time.Duration(cfg.ModuleTimeoutMs)*time.Millisecond)
+	for i, m := range enabled {
+		if _, ok := results[i].(timedOut); ok {
+			info.TimedOut = append(info.TimedOut, m.Name)
+		} else if !empty(results[i]) {
+			info.Values[m.Name] = results[i]
 		}
 	}
 
-	if cfg.ShowTerminal {
-		info.Terminal = getTerminal()
-	}
+	return info, nil
+}
 
-	if cfg.ShowDE {
-		info.DE = getDE()
+func getNetworkAddr(ctx context.Context) string {
+	ifaces, err := net.InterfacesWithContext(ctx)
+	if err != nil {
+		return ""
 	}
-	
-	if cfg.ShowWM {
-		wms := map[string]bool{
-			"i3": true, "bspwm": true, "sway": true, "dwm": true, "awesome": true, "xmonad": true, "openbox": true,
+	for _, i := range ifaces {
+		isLoopback := false
+		for _, flag := range i.Flags {
+			if flag == "loopback" {
+				isLoopback = true
+				break
+			}
 		}
-		if wms[info.DE] {
-			info.WM = info.DE
-			info.DE = "" 
-		} else {
-			info.WM = getWM() 
+		if isLoopback { continue }
+		for _, addr := range i.Addrs {
+			if strings.Contains(addr.Addr, ".") {
+				return addr.Addr
+			}
 		}
 	}
-
-	if cfg.ShowWMTheme {
-		info.WMTheme = "" 
-	}
-
-	if cfg.ShowTheme {
-		info.Theme = getTheme()
-	}
-
-	if cfg.ShowIcons {
-		info.Icons = getIcons()
-	}
-
-	if cfg.ShowPackages {
-		info.Packages = getPackages()
-	}
-
-	return info, nil
-}
-
-func formatDuration(d time.Duration) string {
-	h := d / time.Hour
-	d -= h * time.Hour
-	m := d / time.Minute
-	return fmt.Sprintf("%d hours, %d mins", h, m)
+	return ""
 }
 
 func getModel() string {
@@ -212,55 +145,6 @@ func getModel() string {
 	return "Unknown"
 }
 
-func getGPU() []string {
-	path, err := exec.LookPath("lspci")
-	if err != nil {
-		return nil
-	}
-	out, err := exec.Command(path, "-mm").Output()
-	if err != nil {
-		return nil
-	}
-	
-	lines := strings.Split(string(out), "\n")
-	var gpus []string
-	for _, line := range lines {
-		if line == "" { continue }
-		lower := strings.ToLower(line)
-		if strings.Contains(lower, "vga") || strings.Contains(lower, "3d controller") || strings.Contains(lower, "display controller") {
-			parts := strings.Split(line, "\"")
-			if len(parts) >= 6 {
-				vendor := parts[3]
-				device := parts[5]
-				gpuName := fmt.Sprintf("%s %s", vendor, device)
-				gpus = append(gpus, gpuName)
-			}
-		}
-	}
-	return gpus
-}
-
-func getResolution() string {
-	path, err := exec.LookPath("xrandr")
-	if err != nil {
-		return ""
-	}
-	out, err := exec.Command(path).Output()
-	if err != nil {
-		return ""
-	}
-	lines := strings.Split(string(out), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "*") {
-			fields := strings.Fields(line)
-			if len(fields) > 0 {
-				return fields[0] 
-			}
-		}
-	}
-	return ""
-}
-
 func getDE() string {
 	de := os.Getenv("XDG_CURRENT_DESKTOP")
 	if de == "" {
@@ -269,55 +153,6 @@ func getDE() string {
 	return de
 }
 
-func getWM() string {
-	return "Unknown" 
-}
-
-func getPackages() string {
-	var counts []string
-
-	// Pacman
-	if _, err := exec.LookPath("pacman"); err == nil {
-		out, _ := exec.Command("pacman", "-Qq").Output()
-		if len(out) > 0 {
-			counts = append(counts, fmt.Sprintf("%d (pacman)", strings.Count(string(out), "\n")))
-		}
-	}
-	// Dpkg
-	if _, err := exec.LookPath("dpkg"); err == nil {
-		out, _ := exec.Command("dpkg-query", "-f", "${binary:Package}\n", "-W").Output()
-		if len(out) > 0 {
-			counts = append(counts, fmt.Sprintf("%d (dpkg)", strings.Count(string(out), "\n")))
-		}
-	}
-	// Rpm
-	if _, err := exec.LookPath("rpm"); err == nil {
-		out, _ := exec.Command("rpm", "-qa").Output()
-		if len(out) > 0 {
-			counts = append(counts, fmt.Sprintf("%d (rpm)", strings.Count(string(out), "\n")))
-		}
-	}
-	// Snap
-	if _, err := exec.LookPath("snap"); err == nil {
-		out, _ := exec.Command("snap", "list").Output()
-		if len(out) > 0 {
-			lines := strings.Count(string(out), "\n")
-			if lines > 1 { // header row
-				counts = append(counts, fmt.Sprintf("%d (snap)", lines-1))
-			}
-		}
-	}
-	// Flatpak
-	if _, err := exec.LookPath("flatpak"); err == nil {
-		out, _ := exec.Command("flatpak", "list", "--app").Output()
-		if len(out) > 0 {
-			counts = append(counts, fmt.Sprintf("%d (flatpak)", strings.Count(string(out), "\n")))
-		}
-	}
-
-	return strings.Join(counts, ", ")
-}
-
 func getTerminal() string {
     // 1. Check Standard Env Vars
     if tp := os.Getenv("TERM_PROGRAM"); tp != "" { return tp }
@@ -0,0 +1,57 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSystemInfoMarshalJSON(t *testing.T) {
+	ac := false
+	info := SystemInfo{
+		User:     "me",
+		Hostname: "box",
+		OS:       OSRelease{Name: "Arch Linux", PrettyName: "Arch Linux", ID: "arch", Arch: "x86_64"},
+		Values: map[string]any{
+			"kernel":    "6.9.1-arch1-1",
+			"gpu":       []string{"AMD Radeon RX 6800", "Intel UHD Graphics 770"},
+			"uptime":    26*time.Hour + 3*time.Minute + 59*time.Second,
+			"cpu_usage": Percent(12.5),
+			"memory":    Usage{Used: 4 << 30, Total: 16 << 30, Percent: 25},
+			"packages":  []PackageCount{{"pacman", 1203}, {"flatpak", 12}},
+			"battery":   Battery{Batteries: []BatteryStatus{{Name: "BAT0", Percent: 80, Status: "Discharging", RemainingSeconds: 14400}}, ACOnline: &ac},
+		},
+		TimedOut: []string{"network_usage"},
+	}
+
+	got, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "systeminfo.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(want)) {
+		t.Errorf("MarshalJSON() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Converting durations must not touch the caller's values
+	if _, ok := info.Values["uptime"].(time.Duration); !ok {
+		t.Errorf("MarshalJSON() changed Values[uptime] to %T", info.Values["uptime"])
+	}
+}
+
+func TestSystemInfoMarshalJSONEmpty(t *testing.T) {
+	got, err := json.Marshal(SystemInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// No timed_out key when everything finished
+	if bytes.Contains(got, []byte("timed_out")) {
+		t.Errorf("MarshalJSON() = %s, want no timed_out", got)
+	}
+}
@@ -0,0 +1,63 @@
+package fetcher
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const pciDevicesDir = "/sys/bus/pci/devices"
+
+type pciDevice struct {
+	Vendor string // Lowercase hex without 0x, e.g. 10de
+	Device string
+}
+
+// readDisplayDevices returns every PCI function whose class is a display
+// controller (base class 0x03: VGA, XGA, 3D or other display).
+func readDisplayDevices(dir string) []pciDevice {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*"))
+	sort.Strings(paths)
+
+	var devices []pciDevice
+	for _, path := range paths {
+		class := readSysString(filepath.Join(path, "class"))
+		if !strings.HasPrefix(class, "0x03") {
+			continue
+		}
+		devices = append(devices, pciDevice{
+			Vendor: strings.TrimPrefix(strings.ToLower(readSysString(filepath.Join(path, "vendor"))), "0x"),
+			Device: strings.TrimPrefix(strings.ToLower(readSysString(filepath.Join(path, "device"))), "0x"),
+		})
+	}
+	return devices
+}
+
+func getGPU() []string {
+	devices := readDisplayDevices(pciDevicesDir)
+	if len(devices) == 0 {
+		return nil
+	}
+
+	vendors := make(map[string]bool)
+	for _, d := range devices {
+		vendors[d.Vendor] = true
+	}
+	names := loadPCINames(vendors)
+
+	var gpus []string
+	for _, d := range devices {
+		vendor, ok := names.Vendors[d.Vendor]
+		if !ok {
+			vendor = "Vendor " + d.Vendor
+		}
+		device, ok := names.Devices[d.Vendor][d.Device]
+		if !ok {
+			// Same placeholder lspci uses for unknown IDs
+			device = "Device " + d.Device
+		}
+		gpus = append(gpus, fmt.Sprintf("%s %s", vendor, device))
+	}
+	return gpus
+}
@@ -0,0 +1,211 @@
+package fetcher
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"pulsefetch/config"
+	"pulsefetch/module"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// Built-in modules, in the order they are displayed.
+func init() {
+	module.Register(module.Module{Name: "os", Label: "OS", Default: true, Collect: collectOS})
+	module.Register(module.Module{Name: "host", Label: "Host", Default: true, Collect: func(ctx context.Context, cfg *config.Config) any {
+		return single(getModel())
+	}})
+	module.Register(module.Module{Name: "kernel", Label: "Kernel", Default: true, Collect: collectKernel})
+	module.Register(module.Module{Name: "uptime", Label: "Uptime", Default: true, Collect: collectUptime})
+	module.Register(module.Module{Name: "packages", Label: "Packages", Default: true, Collect: func(ctx context.Context, cfg *config.Config) any {
+		return completePackages(countPackages(ctx, packageCounters))
+	}, Sources: func(cfg *config.Config) []string {
+		return counterSources(packageCounters)
+	}})
+	module.Register(module.Module{Name: "dev_packages", Label: "Dev Packages", Collect: func(ctx context.Context, cfg *config.Config) any {
+		return completePackages(countPackages(ctx, devPackageCounters))
+	}, Sources: func(cfg *config.Config) []string {
+		return counterSources(devPackageCounters)
+	}})
+	module.Register(module.Module{Name: "shell", Label: "Shell", Default: true, Collect: collectShell})
+	module.Register(module.Module{Name: "resolution", Label: "Resolution", Default: true, Multi: true, Collect: func(ctx context.Context, cfg *config.Config) any {
+		return getResolutions(ctx)
+	}})
+	module.Register(module.Module{Name: "de", Label: "DE", Default: true, Collect: collectDE})
+	module.Register(module.Module{Name: "wm", Label: "WM", Default: true, Collect: collectWM})
+	module.Register(module.Module{Name: "wm_theme", Label: "WM Theme", Collect: func(ctx context.Context, cfg *config.Config) any {
+		return single(getWMTheme(currentWM()))
+	}})
+	module.Register(module.Module{Name: "theme", Label: "Theme", Collect: func(ctx context.Context, cfg *config.Config) any {
+		return single(getTheme())
+	}})
+	module.Register(module.Module{Name: "icons", Label: "Icons", Collect: func(ctx context.Context, cfg *config.Config) any {
+		return single(getIcons())
+	}})
+	module.Register(module.Module{Name: "terminal", Label: "Terminal", Default: true, Collect: func(ctx context.Context, cfg *config.Config) any {
+		return single(getTerminal())
+	}})
+	module.Register(module.Module{Name: "cpu", Label: "CPU", Default: true, Collect: collectCPU})
+	module.Register(module.Module{Name: "cpu_usage", Label: "CPU Usage", Collect: collectCPUUsage})
+	module.Register(module.Module{Name: "gpu", Label: "GPU", Default: true, Multi: true, Collect: func(ctx context.Context, cfg *config.Config) any {
+		return getGPU()
+	}, Sources: func(cfg *config.Config) []string {
+		// GPUs only change across reboots, or when pci.ids is updated
+		return append([]string{"/proc/sys/kernel/random/boot_id"}, pciIDsPaths...)
+	}})
+	module.Register(module.Module{Name: "memory", Label: "Memory", Default: true, Collect: collectMemory})
+	module.Register(module.Module{Name: "memory_usage", Label: "Memory Usage", Collect: collectMemoryUsage})
+	module.Register(module.Module{Name: "disk", Label: "Disk", Default: true, Collect: collectDisk})
+	module.Register(module.Module{Name: "disk_usage", Label: "Disk Usage", Collect: collectDiskUsage})
+	module.Register(module.Module{Name: "network", Label: "Network", Collect: func(ctx context.Context, cfg *config.Config) any {
+		return single(getNetworkAddr(ctx))
+	}})
+	module.Register(module.Module{Name: "network_usage", Label: "Network Usage", Multi: true, Collect: func(ctx context.Context, cfg *config.Config) any {
+		return getNetworkUsage(ctx, networkSampleWindow(cfg))
+	}, Timeout: func(cfg *config.Config) time.Duration {
+		// Sampling takes the whole window, plus time to read the counters
+		return networkSampleWindow(cfg) + 250*time.Millisecond
+	}})
+	module.Register(module.Module{Name: "battery", Label: "Battery", Default: true, Collect: func(ctx context.Context, cfg *config.Config) any {
+		return getBattery()
+	}})
+	module.Register(module.Module{Name: "sensors", Label: "Sensors", Collect: func(ctx context.Context, cfg *config.Config) any {
+		return getSensors()
+	}})
+}
+
+// Options that change how another module renders rather than adding a line
+// of their own. They share the show_ prefix for backwards compatibility.
+var moduleOptions = []string{"battery_usage", "sensors_usage"}
+
+// ModuleOptions returns the show_<name> keys that aren't modules.
+func ModuleOptions() []string {
+	return append([]string(nil), moduleOptions...)
+}
+
+func networkSampleWindow(cfg *config.Config) time.Duration {
+	return time.Duration(cfg.NetworkSampleMs) * time.Millisecond
+}
+
+// completePackages hides a package list that was cut short; the runner
+// then shows the module as timed out.
+func completePackages(counts []PackageCount, complete bool) any {
+	if !complete {
+		return nil
+	}
+	return counts
+}
+
+// currentWM is shared by the DE, WM and WM Theme modules so the X server or
+// /proc is only queried once.
+var currentWM = sync.OnceValue(getWM)
+
+func collectOS(ctx context.Context, cfg *config.Config) any {
+	return single(currentOSRelease().String())
+}
+
+func collectKernel(ctx context.Context, cfg *config.Config) any {
+	v, err := host.KernelVersionWithContext(ctx)
+	if err != nil {
+		return nil
+	}
+	return single(v)
+}
+
+func collectUptime(ctx context.Context, cfg *config.Config) any {
+	secs, err := host.UptimeWithContext(ctx)
+	if err != nil {
+		return nil
+	}
+	return time.Duration(secs) * time.Second
+}
+
+func collectShell(ctx context.Context, cfg *config.Config) any {
+	shell := os.Getenv("SHELL")
+	if shell != "" {
+		parts := strings.Split(shell, "/")
+		shell = parts[len(parts)-1]
+	}
+	return single(shell)
+}
+
+// collectDE hides the DE line when XDG_CURRENT_DESKTOP actually names the
+// window manager, which the WM line already shows.
+func collectDE(ctx context.Context, cfg *config.Config) any {
+	de := getDE()
+	if cfg.Show("wm", true) && (tilingDEs[de] || sameWM(de, currentWM())) {
+		return nil
+	}
+	return single(de)
+}
+
+func collectWM(ctx context.Context, cfg *config.Config) any {
+	wm := currentWM()
+	if de := getDE(); wm == "" && tilingDEs[de] {
+		wm = de
+	}
+	return single(wm)
+}
+
+func collectCPU(ctx context.Context, cfg *config.Config) any {
+	c, err := cpu.InfoWithContext(ctx)
+	if err != nil || len(c) == 0 {
+		return nil
+	}
+	return single(c[0].ModelName)
+}
+
+func collectCPUUsage(ctx context.Context, cfg *config.Config) any {
+	percent, err := cpu.PercentWithContext(ctx, 0, false)
+	if err != nil || len(percent) == 0 {
+		return nil
+	}
+	return Percent(percent[0])
+}
+
+func collectMemory(ctx context.Context, cfg *config.Config) any {
+	v, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return nil
+	}
+	return Usage{Used: v.Used, Total: v.Total, Percent: v.UsedPercent}
+}
+
+func collectMemoryUsage(ctx context.Context, cfg *config.Config) any {
+	v, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return nil
+	}
+	return Percent(v.UsedPercent)
+}
+
+func collectDisk(ctx context.Context, cfg *config.Config) any {
+	u, err := disk.UsageWithContext(ctx, "/")
+	if err != nil {
+		return nil
+	}
+	return Usage{Used: u.Used, Total: u.Total, Percent: u.UsedPercent}
+}
+
+func collectDiskUsage(ctx context.Context, cfg *config.Config) any {
+	u, err := disk.UsageWithContext(ctx, "/")
+	if err != nil {
+		return nil
+	}
+	return Percent(u.UsedPercent)
+}
+
+// single returns a one-line value, hiding the module when it is empty.
+func single(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package fetcher
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// getNetworkUsage samples the per-interface counters twice, window apart,
+// and returns each interface's current RX/TX rates and the totals moved
+// since boot. Loopback and idle interfaces are skipped.
+func getNetworkUsage(ctx context.Context, window time.Duration) []NetworkRate {
+	before, err := net.IOCountersWithContext(ctx, true)
+	if err != nil {
+		return nil
+	}
+	start := time.Now()
+	select {
+	case <-time.After(window):
+	case <-ctx.Done():
+		return nil
+	}
+	after, err := net.IOCountersWithContext(ctx, true)
+	if err != nil {
+		return nil
+	}
+	elapsed := time.Since(start).Seconds()
+	if elapsed <= 0 {
+		return nil
+	}
+
+	prev := make(map[string]net.IOCountersStat, len(before))
+	for _, c := range before {
+		prev[c.Name] = c
+	}
+
+	sort.Slice(after, func(i, j int) bool { return after[i].Name < after[j].Name })
+
+	var rates []NetworkRate
+	for _, c := range after {
+		if c.Name == "lo" || (c.BytesRecv == 0 && c.BytesSent == 0) {
+			continue
+		}
+		p, ok := prev[c.Name]
+		if !ok {
+			continue
+		}
+		rates = append(rates, NetworkRate{
+			Interface:     c.Name,
+			RxBytesPerSec: float64(c.BytesRecv-p.BytesRecv) / elapsed,
+			TxBytesPerSec: float64(c.BytesSent-p.BytesSent) / elapsed,
+			RxBytes:       c.BytesRecv,
+			TxBytes:       c.BytesSent,
+		})
+	}
+	return rates
+}
@@ -0,0 +1,151 @@
+package fetcher
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// OSRelease is the parsed contents of os-release(5), with the machine
+// architecture alongside.
+type OSRelease struct {
+	Name            string   `json:"name"`
+	PrettyName      string   `json:"pretty_name"`
+	ID              string   `json:"id"`
+	IDLike          []string `json:"id_like,omitempty"`
+	Version         string   `json:"version,omitempty"`
+	VersionID       string   `json:"version_id,omitempty"`
+	VersionCodename string   `json:"version_codename,omitempty"`
+	Variant         string   `json:"variant,omitempty"`
+	VariantID       string   `json:"variant_id,omitempty"`
+	BuildID         string   `json:"build_id,omitempty"`
+	ANSIColor       string   `json:"ansi_color,omitempty"`
+	Logo            string   `json:"logo,omitempty"`
+	Arch            string   `json:"arch"`
+}
+
+// osReleasePaths are tried in order, as os-release(5) specifies.
+var osReleasePaths = []string{"/etc/os-release", "/usr/lib/os-release"}
+
+const lsbReleasePath = "/etc/lsb-release"
+
+// parseEnvFile reads the KEY=value format shared by os-release and
+// lsb-release, unquoting values.
+func parseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		vars[strings.TrimSpace(k)] = unquoteShell(strings.TrimSpace(v))
+	}
+	return vars, scanner.Err()
+}
+
+// unquoteShell strips one level of single or double quotes. Inside double
+// quotes, backslash escapes the next character.
+func unquoteShell(v string) string {
+	if len(v) < 2 {
+		return v
+	}
+	switch {
+	case v[0] == '\'' && v[len(v)-1] == '\'':
+		return v[1 : len(v)-1]
+	case v[0] == '"' && v[len(v)-1] == '"':
+		var b strings.Builder
+		inner := v[1 : len(v)-1]
+		for i := 0; i < len(inner); i++ {
+			if inner[i] == '\\' && i+1 < len(inner) {
+				i++
+			}
+			b.WriteByte(inner[i])
+		}
+		return b.String()
+	}
+	return v
+}
+
+// readOSRelease parses the first of paths that exists, falling back to
+// lsb-release on systems that predate os-release.
+func readOSRelease(paths []string, lsbPath string) OSRelease {
+	var r OSRelease
+	for _, path := range paths {
+		vars, err := parseEnvFile(path)
+		if err != nil {
+			continue
+		}
+		r = OSRelease{
+			Name:            vars["NAME"],
+			PrettyName:      vars["PRETTY_NAME"],
+			ID:              vars["ID"],
+			IDLike:          strings.Fields(vars["ID_LIKE"]),
+			Version:         vars["VERSION"],
+			VersionID:       vars["VERSION_ID"],
+			VersionCodename: vars["VERSION_CODENAME"],
+			Variant:         vars["VARIANT"],
+			VariantID:       vars["VARIANT_ID"],
+			BuildID:         vars["BUILD_ID"],
+			ANSIColor:       vars["ANSI_COLOR"],
+			Logo:            vars["LOGO"],
+		}
+		break
+	}
+
+	// lsb-release only fills in what os-release left out
+	if r.ID == "" {
+		if vars, err := parseEnvFile(lsbPath); err == nil {
+			setIfEmpty(&r.Name, vars["DISTRIB_ID"])
+			setIfEmpty(&r.ID, strings.ToLower(vars["DISTRIB_ID"]))
+			setIfEmpty(&r.VersionID, vars["DISTRIB_RELEASE"])
+			setIfEmpty(&r.VersionCodename, vars["DISTRIB_CODENAME"])
+			setIfEmpty(&r.PrettyName, vars["DISTRIB_DESCRIPTION"])
+		}
+	}
+
+	// Defaults from os-release(5)
+	if r.Name == "" {
+		r.Name = "Linux"
+	}
+	if r.ID == "" {
+		r.ID = "linux"
+	}
+	if r.PrettyName == "" {
+		r.PrettyName = strings.TrimSpace(r.Name + " " + r.Version)
+	}
+
+	if arch, err := host.KernelArch(); err == nil {
+		r.Arch = arch
+	}
+	return r
+}
+
+func setIfEmpty(field *string, v string) {
+	if *field == "" {
+		*field = v
+	}
+}
+
+// currentOSRelease is read once and shared by the OS module, the logo and
+// accent colour lookups.
+var currentOSRelease = sync.OnceValue(func() OSRelease {
+	return readOSRelease(osReleasePaths, lsbReleasePath)
+})
+
+func (r OSRelease) String() string {
+	return strings.TrimSpace(r.PrettyName + " " + r.Arch)
+}
@@ -0,0 +1,110 @@
+package fetcher
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadOSRelease(t *testing.T) {
+	dir := filepath.Join("testdata", "osrelease")
+	lsb := filepath.Join(dir, "lsb-release")
+
+	tests := []struct {
+		name  string
+		paths []string
+		lsb   string
+		want  OSRelease
+	}{
+		{
+			name:  "arch",
+			paths: []string{"arch"},
+			want: OSRelease{Name: "Arch Linux", PrettyName: "Arch Linux", ID: "arch", BuildID: "rolling",
+				ANSIColor: "38;2;23;147;209", Logo: "archlinux-logo"},
+		},
+		{
+			name:  "ubuntu",
+			paths: []string{"ubuntu"},
+			lsb:   lsb,
+			want: OSRelease{Name: "Ubuntu", PrettyName: "Ubuntu 22.04.4 LTS", ID: "ubuntu", IDLike: []string{"debian"},
+				Version: "22.04.4 LTS (Jammy Jellyfish)", VersionID: "22.04", VersionCodename: "jammy"},
+		},
+		{
+			name:  "variant and single quotes",
+			paths: []string{"fedora-silverblue"},
+			want: OSRelease{Name: "Fedora Linux", PrettyName: "Fedora Linux 39 (Silverblue)", ID: "fedora",
+				Version: "39 (Silverblue)", VersionID: "39", Variant: "Silverblue", VariantID: "silverblue"},
+		},
+		{
+			name:  "first existing path wins",
+			paths: []string{"missing", "arch", "ubuntu"},
+			want: OSRelease{Name: "Arch Linux", PrettyName: "Arch Linux", ID: "arch", BuildID: "rolling",
+				ANSIColor: "38;2;23;147;209", Logo: "archlinux-logo"},
+		},
+		{
+			name:  "lsb-release only",
+			paths: []string{"missing"},
+			lsb:   lsb,
+			want: OSRelease{Name: "Ubuntu", PrettyName: "Ubuntu 10.04.4 LTS", ID: "ubuntu",
+				VersionID: "10.04", VersionCodename: "lucid"},
+		},
+		{
+			// lsb-release must not replace the names os-release gave
+			name:  "os-release without ID",
+			paths: []string{"no-id"},
+			lsb:   lsb,
+			want: OSRelease{Name: "Custom Linux", PrettyName: `Custom Linux "Edge"`, ID: "ubuntu",
+				VersionID: "10.04", VersionCodename: "lucid"},
+		},
+		{
+			name:  "nothing",
+			paths: []string{"missing"},
+			lsb:   filepath.Join(dir, "missing"),
+			want:  OSRelease{Name: "Linux", PrettyName: "Linux", ID: "linux"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := make([]string, len(tt.paths))
+			for i, p := range tt.paths {
+				paths[i] = filepath.Join(dir, p)
+			}
+			lsbPath := tt.lsb
+			if lsbPath == "" {
+				lsbPath = filepath.Join(dir, "missing")
+			}
+			got := readOSRelease(paths, lsbPath)
+			// Arch comes from the running kernel
+			got.Arch = ""
+			if len(got.IDLike) == 0 {
+				got.IDLike = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readOSRelease() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnquoteShell(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`plain`, `plain`},
+		{`"double quoted"`, `double quoted`},
+		{`'single quoted'`, `single quoted`},
+		{`"escaped \"quotes\" and \\ backslash"`, `escaped "quotes" and \ backslash`},
+		{`'no \"escapes\" here'`, `no \"escapes\" here`},
+		{`"unterminated`, `"unterminated`},
+		{`"`, `"`},
+		{`""`, ``},
+		{``, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := unquoteShell(tt.in); got != tt.want {
+				t.Errorf("unquoteShell(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package fetcher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// packageCounter counts the packages installed by one package manager,
+// returning 0 when it isn't present. Sources lists the files or directories
+// that change whenever the count does, for the module cache.
+type packageCounter struct {
+	Name    string
+	Count   func(ctx context.Context) int
+	Sources func() []string
+}
+
+// paths returns a Sources func for a fixed list of paths.
+func paths(p ...string) func() []string {
+	return func() []string { return p }
+}
+
+// packageCounters are tried in this order; Name is the label shown as "N (name)".
+var packageCounters = []packageCounter{
+	{"pacman", countPacman, paths("/var/lib/pacman/local")},
+	{"dpkg", countDpkg, paths("/var/lib/dpkg/status")},
+	{"rpm", countRpm, func() []string { return append([]string{"/var/lib/rpm"}, rpmDBPaths...) }},
+	{"snap", countSnap, paths("/snap")},
+	{"flatpak", countFlatpak, flatpakSources},
+	{"nix-system", countNixSystem, paths("/run/current-system/sw")},
+	{"nix-user", countNixUser, nixUserProfiles},
+	{"nix-default", countNixDefault, paths("/nix/var/nix/profiles/default")},
+	{"apk", countApk, paths("/lib/apk/db/installed")},
+	{"xbps", countXbps, paths("/var/db/xbps")},
+	{"portage", countPortage, portageSources},
+	{"guix-system", countGuixSystem, paths("/run/current-system/profile/manifest")},
+	{"guix-user", countGuixUser, func() []string { return []string{homePath(".guix-profile")} }},
+	{"eopkg", countEopkg, paths("/var/lib/eopkg/package")},
+	{"brew", countBrew, brewSources("Cellar")},
+	{"brew-cask", countBrewCask, brewSources("Caskroom")},
+	{"appimage", countAppImage, func() []string { return []string{homePath("Applications")} }},
+}
+
+// counterSources gathers the sources of every counter in the list.
+func counterSources(counters []packageCounter) []string {
+	var all []string
+	for _, pc := range counters {
+		all = append(all, pc.Sources()...)
+	}
+	return all
+}
+
+// countPackages runs each counter in order, keeping the managers that have
+// packages installed. complete is false if ctx was cancelled before every
+// counter finished; counters that exec a tool return 0 when killed, so the
+// counts are then unreliable.
+func countPackages(ctx context.Context, counters []packageCounter) (counts []PackageCount, complete bool) {
+	for _, pc := range counters {
+		if ctx.Err() != nil {
+			return counts, false
+		}
+		if n := pc.Count(ctx); n > 0 {
+			counts = append(counts, PackageCount{Manager: pc.Name, Count: n})
+		}
+	}
+	return counts, ctx.Err() == nil
+}
+
+// countDirs counts the subdirectories of dir.
+func countDirs(dir string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		if e.IsDir() {
+			n++
+		}
+	}
+	return n
+}
+
+// countPacman counts the per-package directories in the local database.
+// The only other entry there is the ALPM_DB_VERSION file.
+func countPacman(ctx context.Context) int {
+	return countDirs("/var/lib/pacman/local")
+}
+
+// countDpkg counts the stanzas in the status file whose Status field marks
+// the package as installed.
+func countDpkg(ctx context.Context) int {
+	f, err := os.Open("/var/lib/dpkg/status")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if bytes.Equal(scanner.Bytes(), []byte("Status: install ok installed")) {
+			n++
+		}
+	}
+	return n
+}
+
+// rpmDBPaths are the locations of the SQLite rpm database, newest first.
+var rpmDBPaths = []string{
+	"/usr/lib/sysimage/rpm/rpmdb.sqlite",
+	"/var/lib/rpm/rpmdb.sqlite",
+}
+
+// countRpm reads the Packages table of rpmdb.sqlite. Older Berkeley DB and
+// ndb databases can't be read natively, so those still go through rpm.
+func countRpm(ctx context.Context) int {
+	for _, path := range rpmDBPaths {
+		db, err := openSQLite(path)
+		if err != nil {
+			continue
+		}
+		n, err := db.countRows("Packages")
+		db.Close()
+		if err == nil {
+			return n
+		}
+	}
+
+	if _, err := os.Stat("/var/lib/rpm"); err != nil {
+		return 0
+	}
+	if _, err := exec.LookPath("rpm"); err != nil {
+		return 0
+	}
+	out, _ := exec.CommandContext(ctx, "rpm", "-qa").Output()
+	return strings.Count(string(out), "\n")
+}
+
+// countSnap counts the mounted snaps under /snap; /snap/bin holds the
+// command wrappers rather than a snap.
+func countSnap(ctx context.Context) int {
+	entries, err := os.ReadDir("/snap")
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != "bin" {
+			n++
+		}
+	}
+	return n
+}
+
+func flatpakDirs() []string {
+	dirs := []string{"/var/lib/flatpak"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".local", "share", "flatpak"))
+	}
+	return dirs
+}
+
+// flatpakSources are the app directories, whose mtimes change as apps are
+// installed or removed.
+func flatpakSources() []string {
+	var sources []string
+	for _, dir := range flatpakDirs() {
+		sources = append(sources, filepath.Join(dir, "app"))
+	}
+	return sources
+}
+
+// countFlatpak counts applications with at least one deployed branch in the
+// system and per-user installations. Runtimes are skipped to match
+// `flatpak list --app`.
+func countFlatpak(ctx context.Context) int {
+	n := 0
+	for _, dir := range flatpakDirs() {
+		// app/<id>/<arch>/<branch>/active points at the deployed commit
+		active, _ := filepath.Glob(filepath.Join(dir, "app", "*", "*", "*", "active"))
+		seen := make(map[string]bool)
+		for _, a := range active {
+			id := filepath.Base(filepath.Dir(filepath.Dir(filepath.Dir(a))))
+			if !seen[id] {
+				seen[id] = true
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// countNixClosure counts the store paths in a profile's closure, which is
+// how Nix users expect installed packages to be counted. The closure lives
+// in the Nix database, so this asks nix-store rather than reading it.
+func countNixClosure(ctx context.Context, profile string) int {
+	if _, err := os.Stat(profile); err != nil {
+		return 0
+	}
+	path, err := exec.LookPath("nix-store")
+	if err != nil {
+		return 0
+	}
+	out, err := exec.CommandContext(ctx, path, "--query", "--requisites", profile).Output()
+	if err != nil {
+		return 0
+	}
+	return bytes.Count(out, []byte("\n"))
+}
+
+func countNixSystem(ctx context.Context) int {
+	return countNixClosure(ctx, "/run/current-system/sw")
+}
+
+func nixUserProfiles() []string {
+	var profiles []string
+	if u := currentUsername(); u != "" {
+		profiles = append(profiles, filepath.Join("/etc/profiles/per-user", u))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		profiles = append(profiles,
+			filepath.Join(home, ".local", "state", "nix", "profiles", "home-manager", "home-path"),
+			filepath.Join(home, ".nix-profile"))
+	}
+	return profiles
+}
+
+// countNixUser counts the per-user profile that NixOS users.users.packages
+// and home-manager install into, falling back to the nix-env or nix
+// profile in $HOME.
+func countNixUser(ctx context.Context) int {
+	for _, profile := range nixUserProfiles() {
+		if n := countNixClosure(ctx, profile); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+func countNixDefault(ctx context.Context) int {
+	return countNixClosure(ctx, "/nix/var/nix/profiles/default")
+}
+
+var currentUsername = sync.OnceValue(func() string {
+	if u := os.Getenv("USER"); u != "" {
+		return u
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+})
+
+// countLinesWithPrefix counts the lines in path that start with prefix.
+func countLinesWithPrefix(path, prefix string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+// countApk counts the P: (package name) records in the installed database.
+func countApk(ctx context.Context) int {
+	return countLinesWithPrefix("/lib/apk/db/installed", "P:")
+}
+
+// countXbps counts packages whose state is installed in the pkgdb plist.
+func countXbps(ctx context.Context) int {
+	matches, _ := filepath.Glob("/var/db/xbps/pkgdb-*.plist")
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		return bytes.Count(data, []byte("<string>installed</string>"))
+	}
+	return 0
+}
+
+// countPortage counts the <category>/<package> directories in the VDB.
+// portageSources include each category directory in /var/db/pkg, since
+// installing into an existing category only changes that directory.
+func portageSources() []string {
+	cats, _ := filepath.Glob("/var/db/pkg/*")
+	return append([]string{"/var/db/pkg", "/var/lib/portage/world"}, cats...)
+}
+
+func countPortage(ctx context.Context) int {
+	pkgs, _ := filepath.Glob("/var/db/pkg/*/*")
+	n := 0
+	for _, p := range pkgs {
+		if st, err := os.Stat(p); err == nil && st.IsDir() {
+			n++
+		}
+	}
+	return n
+}
+
+func countGuixSystem(ctx context.Context) int {
+	return countGuixManifest("/run/current-system/profile/manifest")
+}
+
+func countGuixUser(ctx context.Context) int {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return 0
+	}
+	return countGuixManifest(filepath.Join(home, ".guix-profile", "manifest"))
+}
+
+// countGuixManifest counts the entries of the (packages ...) list in a Guix
+// profile manifest. Each entry is itself a list, and propagated inputs are
+// nested deeper, so only lists directly inside it are counted.
+func countGuixManifest(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	start := bytes.Index(data, []byte("(packages"))
+	if start < 0 {
+		return 0
+	}
+
+	n := 0
+	depth := 0
+	inString := false
+	for i := start; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '(':
+			depth++
+			// depth 1 is (packages, 2 the list, 3 each package entry
+			if depth == 3 {
+				n++
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return n
+			}
+		}
+	}
+	return n
+}
+
+func countEopkg(ctx context.Context) int {
+	return countDirs("/var/lib/eopkg/package")
+}
+
+// brewPrefixes are the Homebrew on Linux install locations.
+func brewPrefixes() []string {
+	prefixes := []string{"/home/linuxbrew/.linuxbrew"}
+	if p := os.Getenv("HOMEBREW_PREFIX"); p != "" && p != prefixes[0] {
+		prefixes = append([]string{p}, prefixes...)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		prefixes = append(prefixes, filepath.Join(home, ".linuxbrew"))
+	}
+	return prefixes
+}
+
+func brewSources(sub string) func() []string {
+	return func() []string {
+		var sources []string
+		for _, p := range brewPrefixes() {
+			sources = append(sources, filepath.Join(p, sub))
+		}
+		return sources
+	}
+}
+
+func countBrew(ctx context.Context) int {
+	for _, p := range brewPrefixes() {
+		if n := countDirs(filepath.Join(p, "Cellar")); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+func countBrewCask(ctx context.Context) int {
+	for _, p := range brewPrefixes() {
+		if n := countDirs(filepath.Join(p, "Caskroom")); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// countAppImage counts the AppImages in ~/Applications.
+func countAppImage(ctx context.Context) int {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return 0
+	}
+	entries, err := os.ReadDir(filepath.Join(home, "Applications"))
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		if !e.IsDir() && strings.EqualFold(filepath.Ext(e.Name()), ".appimage") {
+			n++
+		}
+	}
+	return n
+}
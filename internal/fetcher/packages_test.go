@@ -0,0 +1,26 @@
+package fetcher
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCountGuixManifest(t *testing.T) {
+	tests := []struct {
+		file string
+		want int
+	}{
+		// Propagated inputs and parentheses inside strings aren't counted
+		{"manifest", 3},
+		{"manifest-empty", 0},
+		{"manifest-no-packages", 0},
+		{"missing", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := countGuixManifest(filepath.Join("testdata", "guix", tt.file)); got != tt.want {
+				t.Errorf("countGuixManifest(%q) = %d, want %d", tt.file, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package fetcher
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+)
+
+// pciIDsPaths are the usual locations of the pci.ids database shipped by
+// hwdata or pciutils.
+var pciIDsPaths = []string{
+	"/usr/share/hwdata/pci.ids",
+	"/usr/share/misc/pci.ids",
+	"/usr/share/pci.ids",
+	"/usr/share/misc/pci.ids.gz",
+	"/usr/share/hwdata/pci.ids.gz",
+}
+
+// fallbackPCIVendors is used when no pci.ids file is installed, which is
+// common in containers and minimal images.
+var fallbackPCIVendors = map[string]string{
+	"1002": "Advanced Micro Devices, Inc. [AMD/ATI]",
+	"1013": "Cirrus Logic",
+	"102b": "Matrox Electronics Systems Ltd.",
+	"10de": "NVIDIA Corporation",
+	"1234": "QEMU",
+	"15ad": "VMware",
+	"1a03": "ASPEED Technology, Inc.",
+	"1af4": "Red Hat, Inc.",
+	"1b36": "Red Hat, Inc.",
+	"1ed5": "Moore Threads Technology Co.,Ltd",
+	"5143": "Qualcomm Technologies, Inc",
+	"80ee": "InnoTek Systemberatung GmbH",
+	"8086": "Intel Corporation",
+}
+
+// pciNames maps vendor IDs to their name and device names, keyed by the
+// lowercase four-digit hex IDs used in sysfs and pci.ids.
+type pciNames struct {
+	Vendors map[string]string
+	Devices map[string]map[string]string // vendor -> device -> name
+}
+
+// parsePCIIDs reads a pci.ids stream, keeping only the vendors listed in
+// wanted so the whole database never has to be held in memory.
+func parsePCIIDs(r io.Reader, wanted map[string]bool) pciNames {
+	names := pciNames{
+		Vendors: make(map[string]string),
+		Devices: make(map[string]map[string]string),
+	}
+
+	vendor := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		// Device classes follow the vendor list and aren't needed
+		if strings.HasPrefix(line, "C ") {
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(line, "\t\t"):
+			// Subsystem entry, not used
+		case line[0] == '\t':
+			if vendor == "" {
+				continue
+			}
+			id, name, ok := splitPCIIDLine(line[1:])
+			if ok {
+				names.Devices[vendor][id] = name
+			}
+		default:
+			id, name, ok := splitPCIIDLine(line)
+			if !ok || !wanted[id] {
+				vendor = ""
+				continue
+			}
+			vendor = id
+			names.Vendors[id] = name
+			names.Devices[id] = make(map[string]string)
+		}
+	}
+	return names
+}
+
+// splitPCIIDLine splits "10de  NVIDIA Corporation" into ID and name.
+func splitPCIIDLine(line string) (string, string, bool) {
+	if len(line) < 6 || line[4] != ' ' {
+		return "", "", false
+	}
+	return strings.ToLower(line[:4]), strings.TrimSpace(line[5:]), true
+}
+
+// loadPCINames looks up the given vendors in the first readable pci.ids,
+// filling gaps from the embedded vendor table.
+func loadPCINames(vendors map[string]bool) pciNames {
+	var names pciNames
+	for _, path := range pciIDsPaths {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		var r io.Reader = f
+		if strings.HasSuffix(path, ".gz") {
+			gz, err := gzip.NewReader(f)
+			if err != nil {
+				f.Close()
+				continue
+			}
+			r = gz
+		}
+		names = parsePCIIDs(r, vendors)
+		f.Close()
+		break
+	}
+
+	if names.Vendors == nil {
+		names.Vendors = make(map[string]string)
+		names.Devices = make(map[string]map[string]string)
+	}
+	for v := range vendors {
+		if _, ok := names.Vendors[v]; !ok {
+			if name, ok := fallbackPCIVendors[v]; ok {
+				names.Vendors[v] = name
+			}
+		}
+	}
+	return names
+}
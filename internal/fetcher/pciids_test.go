@@ -0,0 +1,59 @@
+package fetcher
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParsePCIIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		wanted map[string]bool
+		want   pciNames
+	}{
+		{
+			name:   "selected vendors",
+			wanted: map[string]bool{"10de": true, "1af4": true},
+			want: pciNames{
+				Vendors: map[string]string{"10de": "NVIDIA Corporation", "1af4": "Red Hat, Inc."},
+				Devices: map[string]map[string]string{
+					"10de": {"2684": "AD102 [GeForce RTX 4090]", "2704": "AD103 [GeForce RTX 4080]"},
+					"1af4": {"1050": "Virtio 1.0 GPU"},
+				},
+			},
+		},
+		{
+			name:   "subsystems skipped",
+			wanted: map[string]bool{"1002": true},
+			want: pciNames{
+				Vendors: map[string]string{"1002": "Advanced Micro Devices, Inc. [AMD/ATI]"},
+				Devices: map[string]map[string]string{
+					"1002": {
+						"73bf": "Navi 21 [Radeon RX 6800/6800 XT / 6900 XT]",
+						"744c": "Navi 31 [Radeon RX 7900 XT/7900 XTX/7900 GRE/7900M]",
+					},
+				},
+			},
+		},
+		{
+			// Lines after the device class list aren't vendors
+			name:   "stops at classes",
+			wanted: map[string]bool{"1234": true},
+			want:   pciNames{Vendors: map[string]string{}, Devices: map[string]map[string]string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open("testdata/pci.ids")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			if got := parsePCIIDs(f, tt.wanted); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePCIIDs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// getResolutions returns every connected output. Wayland sessions
+// ask the compositor directly, which gives the current mode and output
+// scale. X11 sessions ask the X server through xrandr for the current
+// mode, taking monitor names and sizes from DRM sysfs. DRM alone, which
+// only knows each panel's preferred mode, covers the console and headless
+// machines.
+func getResolutions(ctx context.Context) []Monitor {
+	var monitors []Monitor
+
+	if isWayland() {
+		if outputs, err := waylandOutputs(); err == nil {
+			for _, o := range outputs {
+				if o.Width == 0 || o.Height == 0 {
+					continue
+				}
+				name := strings.TrimSpace(o.Make + " " + o.Model)
+				monitors = append(monitors, Monitor{
+					Connector:      o.Name,
+					Name:           name,
+					Width:          o.Width,
+					Height:         o.Height,
+					RefreshHz:      o.RefreshHz,
+					Scale:          float64(o.Scale),
+					DiagonalInches: displayOutput{WidthMM: o.WidthMM, HeightMM: o.HeightMM}.diagonalInches(),
+				})
+			}
+		}
+	}
+
+	drm := readDRMOutputs(drmDir)
+	triedX := false
+	if len(monitors) == 0 && os.Getenv("DISPLAY") != "" {
+		monitors = xrandrMonitors(ctx)
+		addDRMDetails(monitors, drm)
+		triedX = true
+	}
+
+	if len(monitors) == 0 {
+		for _, o := range drm {
+			monitors = append(monitors, Monitor{
+				Connector:      o.Connector,
+				Name:           o.Name,
+				Width:          o.Width,
+				Height:         o.Height,
+				RefreshHz:      o.RefreshHz,
+				DiagonalInches: o.diagonalInches(),
+			})
+		}
+	}
+
+	if len(monitors) == 0 && !triedX {
+		monitors = xrandrMonitors(ctx)
+	}
+
+	// Wayland reports the scale per output; elsewhere it's session-wide
+	if scale := sessionScale(); scale > 0 {
+		for i := range monitors {
+			if monitors[i].Scale == 0 {
+				monitors[i].Scale = scale
+			}
+		}
+	}
+
+	return monitors
+}
+
+// addDRMDetails fills in the monitor names and sizes the X server doesn't
+// report, matching outputs by connector name. Drivers don't always name
+// connectors the same way in X and in sysfs (DisplayPort-0 vs DP-1), so a
+// lone monitor is matched to a lone DRM output regardless of name.
+func addDRMDetails(monitors []Monitor, drm []displayOutput) {
+	for i := range monitors {
+		for _, o := range drm {
+			if o.Connector == monitors[i].Connector || (len(monitors) == 1 && len(drm) == 1) {
+				monitors[i].Name = o.Name
+				monitors[i].DiagonalInches = o.diagonalInches()
+				break
+			}
+		}
+	}
+}
+
+// sessionScale returns the scale factor toolkits will apply, from
+// GDK_SCALE, QT_SCALE_FACTOR or the Xft.dpi resource, or 0 if none is set.
+func sessionScale() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("GDK_SCALE"), 64); err == nil && v > 0 {
+		if dpi, err := strconv.ParseFloat(os.Getenv("GDK_DPI_SCALE"), 64); err == nil && dpi > 0 {
+			v *= dpi
+		}
+		return v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("QT_SCALE_FACTOR"), 64); err == nil && v > 0 {
+		return v
+	}
+	if display := os.Getenv("DISPLAY"); display != "" {
+		if dpi, err := x11XftDPI(display); err == nil && dpi > 0 {
+			return dpi / 96
+		}
+	}
+	return 0
+}
+
+// xrandrMonitors asks the X server for its outputs through xrandr.
+func xrandrMonitors(ctx context.Context) []Monitor {
+	path, err := exec.LookPath("xrandr")
+	if err != nil {
+		return nil
+	}
+	out, err := exec.CommandContext(ctx, path).Output()
+	if err != nil {
+		return nil
+	}
+	return parseXrandr(string(out))
+}
+
+// parseXrandr parses `xrandr` output: a "<connector> connected" line
+// followed by mode lines, where the current mode's refresh rate is marked
+// with '*'. Outputs that aren't connected are skipped.
+func parseXrandr(out string) []Monitor {
+	var monitors []Monitor
+	connector := ""
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// Mode lines are indented; anything else starts a new output
+		if line[0] != ' ' && line[0] != '\t' {
+			connector = ""
+			if len(fields) >= 2 && fields[1] == "connected" {
+				connector = fields[0]
+			}
+			continue
+		}
+		if connector == "" || !strings.Contains(line, "*") {
+			continue
+		}
+		m := Monitor{Connector: connector}
+		if _, err := fmt.Sscanf(fields[0], "%dx%d", &m.Width, &m.Height); err != nil {
+			continue
+		}
+		for _, f := range fields[1:] {
+			if strings.Contains(f, "*") {
+				m.RefreshHz, _ = strconv.ParseFloat(strings.TrimRight(f, "*+"), 64)
+				break
+			}
+		}
+		monitors = append(monitors, m)
+	}
+	return monitors
+}
@@ -0,0 +1,69 @@
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseXrandr(t *testing.T) {
+	tests := []struct {
+		file string
+		want []Monitor
+	}{
+		// HDMI-1 is disconnected and DP-2 connected but switched off
+		{"dual", []Monitor{
+			{Connector: "eDP-1", Width: 1920, Height: 1080, RefreshHz: 60.01},
+			{Connector: "DP-1", Width: 2560, Height: 1440, RefreshHz: 143.97},
+		}},
+		// A mode left active on an unplugged output isn't a monitor
+		{"stale-mode", []Monitor{
+			{Connector: "HDMI-A-0", Width: 1920, Height: 1080, RefreshHz: 60},
+		}},
+		{"headless", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "xrandr", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := parseXrandr(string(data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseXrandr() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddDRMDetails(t *testing.T) {
+	dell := displayOutput{Connector: "DP-1", Name: "DELL U2415", WidthMM: 518, HeightMM: 324}
+	panel := displayOutput{Connector: "eDP-1", Name: "0x08E8", WidthMM: 309, HeightMM: 174}
+
+	tests := []struct {
+		name     string
+		monitors []Monitor
+		drm      []displayOutput
+		want     []string // Names, in order
+	}{
+		{"by connector", []Monitor{{Connector: "eDP-1"}, {Connector: "DP-1"}}, []displayOutput{dell, panel}, []string{"0x08E8", "DELL U2415"}},
+		// X and the kernel name the same port differently
+		{"lone outputs", []Monitor{{Connector: "DisplayPort-0"}}, []displayOutput{dell}, []string{"DELL U2415"}},
+		{"no match", []Monitor{{Connector: "HDMI-1"}, {Connector: "DP-3"}}, []displayOutput{dell, panel}, []string{"", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addDRMDetails(tt.monitors, tt.drm)
+			var got []string
+			for _, m := range tt.monitors {
+				got = append(got, m.Name)
+				if m.Name != "" && m.DiagonalInches == 0 {
+					t.Errorf("%s: name set without a diagonal", m.Connector)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("names = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
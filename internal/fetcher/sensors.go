@@ -0,0 +1,194 @@
+package fetcher
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	hwmonDir   = "/sys/class/hwmon"
+	thermalDir = "/sys/class/thermal"
+)
+
+// chipCategories maps known hwmon chip names to the summary group they
+// belong to, along with the label that best represents the whole chip.
+var chipCategories = map[string]struct {
+	Category  string
+	Preferred []string
+}{
+	"k10temp":     {"CPU", []string{"Tctl", "Tdie"}},
+	"zenpower":    {"CPU", []string{"Tdie", "Tctl"}},
+	"coretemp":    {"CPU", []string{"Package id 0"}},
+	"cpu_thermal": {"CPU", nil},
+	"amdgpu":      {"GPU", []string{"edge"}},
+	"radeon":      {"GPU", nil},
+	"nouveau":     {"GPU", nil},
+	"nvme":        {"NVMe", []string{"Composite"}},
+	"acpitz":      {"ACPI", nil},
+}
+
+// thermalZoneCategories maps /sys/class/thermal zone types to summary groups.
+var thermalZoneCategories = map[string]string{
+	"x86_pkg_temp": "CPU",
+	"cpu-thermal":  "CPU",
+	"cpu_thermal":  "CPU",
+	"soc_thermal":  "CPU",
+	"acpitz":       "ACPI",
+	"gpu-thermal":  "GPU",
+}
+
+// sensorOrder controls the order groups appear in the summary.
+var sensorOrder = []string{"CPU", "GPU", "NVMe", "ACPI"}
+
+// readHwmon returns one representative reading per hwmon chip plus every
+// non-zero fan speed in RPM.
+func readHwmon(dir string) ([]Temperature, []int64) {
+	chips, _ := filepath.Glob(filepath.Join(dir, "hwmon*"))
+	sort.Strings(chips)
+
+	var readings []Temperature
+	var fans []int64
+	for _, chip := range chips {
+		name := readSysString(filepath.Join(chip, "name"))
+		known, ok := chipCategories[name]
+		category := name
+		if ok {
+			category = known.Category
+		}
+
+		var temps []Temperature
+		inputs, _ := filepath.Glob(filepath.Join(chip, "temp*_input"))
+		sort.Strings(inputs)
+		for _, input := range inputs {
+			prefix := strings.TrimSuffix(input, "_input")
+			v, ok := readSysInt(input)
+			if !ok {
+				continue
+			}
+			r := Temperature{
+				Category: category,
+				Label:    readSysString(prefix + "_label"),
+				Celsius:  float64(v) / 1000,
+			}
+			if m, ok := readSysInt(prefix + "_max"); ok {
+				r.Max = float64(m) / 1000
+			}
+			if c, ok := readSysInt(prefix + "_crit"); ok {
+				r.Crit = float64(c) / 1000
+			}
+			temps = append(temps, r)
+		}
+		if r, ok := pickReading(temps, known.Preferred); ok {
+			readings = append(readings, r)
+		}
+
+		fanInputs, _ := filepath.Glob(filepath.Join(chip, "fan*_input"))
+		sort.Strings(fanInputs)
+		for _, input := range fanInputs {
+			if rpm, ok := readSysInt(input); ok && rpm > 0 {
+				fans = append(fans, rpm)
+			}
+		}
+	}
+	return readings, fans
+}
+
+// pickReading prefers a reading whose label matches one of preferred, then
+// falls back to the hottest one.
+func pickReading(temps []Temperature, preferred []string) (Temperature, bool) {
+	if len(temps) == 0 {
+		return Temperature{}, false
+	}
+	for _, label := range preferred {
+		for _, t := range temps {
+			if t.Label == label {
+				return t, true
+			}
+		}
+	}
+	best := temps[0]
+	for _, t := range temps[1:] {
+		if t.Celsius > best.Celsius {
+			best = t
+		}
+	}
+	return best, true
+}
+
+// readThermalZones reads /sys/class/thermal/thermal_zone* for zone types we
+// know how to classify. The critical trip point, if any, becomes Crit.
+func readThermalZones(dir string) []Temperature {
+	zones, _ := filepath.Glob(filepath.Join(dir, "thermal_zone*"))
+	sort.Strings(zones)
+
+	var readings []Temperature
+	for _, zone := range zones {
+		zoneType := readSysString(filepath.Join(zone, "type"))
+		category, ok := thermalZoneCategories[zoneType]
+		if !ok {
+			continue
+		}
+		v, ok := readSysInt(filepath.Join(zone, "temp"))
+		if !ok {
+			continue
+		}
+		r := Temperature{Category: category, Label: zoneType, Celsius: float64(v) / 1000}
+
+		trips, _ := filepath.Glob(filepath.Join(zone, "trip_point_*_type"))
+		for _, trip := range trips {
+			if readSysString(trip) != "critical" {
+				continue
+			}
+			if c, ok := readSysInt(strings.TrimSuffix(trip, "_type") + "_temp"); ok {
+				r.Crit = float64(c) / 1000
+			}
+		}
+		readings = append(readings, r)
+	}
+	return readings
+}
+
+// collectSensors merges hwmon and thermal zone readings into at most one
+// reading per category. Thermal zones only fill in categories hwmon lacks.
+func collectSensors() ([]Temperature, []int64) {
+	hw, fans := readHwmon(hwmonDir)
+
+	byCategory := make(map[string]Temperature)
+	add := func(r Temperature) {
+		if cur, ok := byCategory[r.Category]; !ok || r.Celsius > cur.Celsius {
+			byCategory[r.Category] = r
+		}
+	}
+	for _, r := range hw {
+		add(r)
+	}
+	for _, r := range readThermalZones(thermalDir) {
+		if _, ok := byCategory[r.Category]; !ok {
+			add(r)
+		}
+	}
+
+	var readings []Temperature
+	for _, c := range sensorOrder {
+		if r, ok := byCategory[c]; ok {
+			readings = append(readings, r)
+			delete(byCategory, c)
+		}
+	}
+	// Unknown chips go last, sorted by name for stable output
+	var rest []string
+	for c := range byCategory {
+		rest = append(rest, c)
+	}
+	sort.Strings(rest)
+	for _, c := range rest {
+		readings = append(readings, byCategory[c])
+	}
+	return readings, fans
+}
+
+func getSensors() Sensors {
+	temps, fans := collectSensors()
+	return Sensors{Temperatures: temps, FanRPM: fans}
+}
@@ -0,0 +1,62 @@
+package fetcher
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadHwmon(t *testing.T) {
+	temps, fans := readHwmon(filepath.Join("testdata", "hwmon"))
+
+	// One reading per chip: the preferred label, else the hottest. The
+	// acpitz chip has no readable input, and stopped fans are skipped.
+	wantTemps := []Temperature{
+		{Category: "CPU", Label: "Tctl", Celsius: 62.5},
+		{Category: "NVMe", Label: "Composite", Celsius: 38.85, Max: 81.85, Crit: 84.85},
+		{Category: "GPU", Label: "edge", Celsius: 50, Crit: 100},
+		{Category: "nct6798", Celsius: 41},
+	}
+	if !reflect.DeepEqual(temps, wantTemps) {
+		t.Errorf("readHwmon() temps = %+v, want %+v", temps, wantTemps)
+	}
+	if want := []int64{1200, 900, 650}; !reflect.DeepEqual(fans, want) {
+		t.Errorf("readHwmon() fans = %v, want %v", fans, want)
+	}
+}
+
+func TestReadThermalZones(t *testing.T) {
+	got := readThermalZones(filepath.Join("testdata", "thermal"))
+
+	// Unknown zone types are skipped; only critical trip points count
+	want := []Temperature{
+		{Category: "CPU", Label: "x86_pkg_temp", Celsius: 55, Crit: 105},
+		{Category: "ACPI", Label: "acpitz", Celsius: 27.8},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readThermalZones() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPickReading(t *testing.T) {
+	temps := []Temperature{{Label: "a", Celsius: 40}, {Label: "b", Celsius: 70}, {Label: "c", Celsius: 50}}
+	tests := []struct {
+		name      string
+		temps     []Temperature
+		preferred []string
+		want      string
+		wantOK    bool
+	}{
+		{"preferred", temps, []string{"missing", "c"}, "c", true},
+		{"hottest", temps, nil, "b", true},
+		{"empty", nil, []string{"a"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := pickReading(tt.temps, tt.preferred)
+			if got.Label != tt.want || ok != tt.wantOK {
+				t.Errorf("pickReading() = %q, %v, want %q, %v", got.Label, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package fetcher
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// A read-only SQLite file reader that understands just enough of the
+// on-disk format to count the rows of a table. It's used for rpmdb.sqlite
+// so package counting doesn't need cgo or the rpm binary.
+//
+// Changes still sitting in the write-ahead log aren't seen, so a count can
+// lag behind a transaction that hasn't been checkpointed yet.
+
+const (
+	sqlitePageLeafTable     = 0x0d
+	sqlitePageInteriorTable = 0x05
+)
+
+var sqliteMagic = []byte("SQLite format 3\x00")
+
+type sqliteDB struct {
+	f        *os.File
+	pageSize int
+	usable   int
+	numPages int
+}
+
+func openSQLite(path string) (*sqliteDB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	head := make([]byte, 100)
+	if _, err := io.ReadFull(f, head); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if string(head[:16]) != string(sqliteMagic) {
+		f.Close()
+		return nil, errors.New("not a SQLite database")
+	}
+
+	pageSize := int(binary.BigEndian.Uint16(head[16:]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	// A power of two between 512 and 65536, with at least 480 usable bytes
+	if pageSize < 512 || pageSize > 65536 || pageSize&(pageSize-1) != 0 || pageSize-int(head[20]) < 480 {
+		f.Close()
+		return nil, fmt.Errorf("invalid SQLite page size %d", pageSize)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &sqliteDB{
+		f:        f,
+		pageSize: pageSize,
+		usable:   pageSize - int(head[20]),
+		numPages: int(st.Size() / int64(pageSize)),
+	}, nil
+}
+
+func (db *sqliteDB) Close() error {
+	return db.f.Close()
+}
+
+// page reads page n (1-based) and returns it along with the offset of the
+// b-tree header, which is 100 on page 1 because of the file header.
+func (db *sqliteDB) page(n int) ([]byte, int, error) {
+	if n < 1 || n > db.numPages {
+		return nil, 0, fmt.Errorf("sqlite page %d out of range", n)
+	}
+	buf := make([]byte, db.pageSize)
+	if _, err := db.f.ReadAt(buf, int64(n-1)*int64(db.pageSize)); err != nil {
+		return nil, 0, err
+	}
+	if n == 1 {
+		return buf, 100, nil
+	}
+	return buf, 0, nil
+}
+
+// walkTable calls leaf for every leaf page of the table b-tree rooted at
+// root, passing the page and the offsets of its cells.
+func (db *sqliteDB) walkTable(root int, leaf func(page []byte, cells []int) error) error {
+	visited := make(map[int]bool)
+	var walk func(n int) error
+	walk = func(n int) error {
+		if visited[n] {
+			return errors.New("sqlite b-tree cycle")
+		}
+		visited[n] = true
+
+		buf, off, err := db.page(n)
+		if err != nil {
+			return err
+		}
+		kind := buf[off]
+		count := int(binary.BigEndian.Uint16(buf[off+3:]))
+		headerLen := 8
+		if kind == sqlitePageInteriorTable {
+			headerLen = 12
+		}
+		cells := make([]int, 0, count)
+		for i := 0; i < count; i++ {
+			p := off + headerLen + i*2
+			if p+2 > len(buf) {
+				return errors.New("sqlite cell pointer out of range")
+			}
+			cells = append(cells, int(binary.BigEndian.Uint16(buf[p:])))
+		}
+
+		switch kind {
+		case sqlitePageLeafTable:
+			return leaf(buf, cells)
+		case sqlitePageInteriorTable:
+			for _, c := range cells {
+				if c+4 > len(buf) {
+					return errors.New("sqlite cell out of range")
+				}
+				if err := walk(int(binary.BigEndian.Uint32(buf[c:]))); err != nil {
+					return err
+				}
+			}
+			return walk(int(binary.BigEndian.Uint32(buf[off+8:])))
+		default:
+			return fmt.Errorf("unexpected sqlite page type %#x", kind)
+		}
+	}
+	return walk(root)
+}
+
+// countRows returns the number of rows in the named table.
+func (db *sqliteDB) countRows(table string) (int, error) {
+	root, err := db.tableRoot(table)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	err = db.walkTable(root, func(page []byte, cells []int) error {
+		total += len(cells)
+		return nil
+	})
+	return total, err
+}
+
+// tableRoot looks the table up in sqlite_master, whose b-tree is rooted at
+// page 1, and returns its root page.
+func (db *sqliteDB) tableRoot(table string) (int, error) {
+	root := 0
+	err := db.walkTable(1, func(page []byte, cells []int) error {
+		for _, c := range cells {
+			payload, ok := db.localPayload(page, c)
+			if !ok {
+				continue
+			}
+			cols := sqliteRecord(payload, 4)
+			if len(cols) < 4 {
+				continue
+			}
+			kind, _ := cols[0].(string)
+			name, _ := cols[1].(string)
+			if kind == "table" && name == table {
+				if n, ok := cols[3].(int64); ok {
+					root = int(n)
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if root == 0 {
+		return 0, fmt.Errorf("sqlite table %q not found", table)
+	}
+	return root, nil
+}
+
+// localPayload returns the part of a leaf cell's payload stored on the page
+// itself. Overflow pages aren't followed; the columns we read from
+// sqlite_master always fit in the local part.
+func (db *sqliteDB) localPayload(page []byte, cell int) ([]byte, bool) {
+	if cell >= len(page) {
+		return nil, false
+	}
+	size, n := sqliteVarint(page[cell:])
+	if n == 0 {
+		return nil, false
+	}
+	_, m := sqliteVarint(page[cell+n:])
+	if m == 0 {
+		return nil, false
+	}
+	start := cell + n + m
+
+	local := int(size)
+	maxLocal := db.usable - 35
+	if local > maxLocal {
+		minLocal := (db.usable-12)*32/255 - 23
+		local = minLocal + (int(size)-minLocal)%(db.usable-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if start+local > len(page) {
+		return nil, false
+	}
+	return page[start : start+local], true
+}
+
+// sqliteVarint decodes a SQLite big-endian varint, returning the value and
+// the number of bytes used (0 on truncated input).
+func sqliteVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < 9; i++ {
+		if i >= len(b) {
+			return 0, 0
+		}
+		if i == 8 {
+			return v<<8 | uint64(b[i]), 9
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return v, 9
+}
+
+// sqliteRecord decodes up to max columns of a record. Integers come back as
+// int64, text as string and anything else as nil. Decoding stops early if
+// the record runs past the end of payload.
+func sqliteRecord(payload []byte, max int) []any {
+	headerLen, n := sqliteVarint(payload)
+	if n == 0 || int(headerLen) > len(payload) {
+		return nil
+	}
+	var types []uint64
+	for p := n; p < int(headerLen) && len(types) < max; {
+		t, m := sqliteVarint(payload[p:])
+		if m == 0 {
+			break
+		}
+		types = append(types, t)
+		p += m
+	}
+
+	var cols []any
+	body := payload[headerLen:]
+	for _, t := range types {
+		var size int
+		switch {
+		case t == 0, t == 8, t == 9:
+			size = 0
+		case t >= 1 && t <= 4:
+			size = int(t)
+		case t == 5:
+			size = 6
+		case t == 6, t == 7:
+			size = 8
+		case t >= 12:
+			size = int(t-12) / 2
+		default:
+			return cols
+		}
+		if size > len(body) {
+			return cols
+		}
+		v := body[:size]
+		body = body[size:]
+
+		switch {
+		case t == 0:
+			cols = append(cols, nil)
+		case t == 8:
+			cols = append(cols, int64(0))
+		case t == 9:
+			cols = append(cols, int64(1))
+		case t >= 1 && t <= 6:
+			// Big-endian two's complement of the given width
+			x := int64(int8(v[0]))
+			for _, b := range v[1:] {
+				x = x<<8 | int64(b)
+			}
+			cols = append(cols, x)
+		case t >= 13 && t%2 == 1:
+			cols = append(cols, string(v))
+		default:
+			cols = append(cols, nil)
+		}
+	}
+	return cols
+}
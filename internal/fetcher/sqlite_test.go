@@ -0,0 +1,57 @@
+package fetcher
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteCountRows(t *testing.T) {
+	tests := []struct {
+		file    string
+		table   string
+		want    int
+		wantErr bool
+	}{
+		{file: "rpmdb.sqlite", table: "Packages", want: 3},
+		{file: "rpmdb.sqlite", table: "Basenames", want: 1},
+		{file: "empty.sqlite", table: "Packages", want: 0},
+		// 512-byte pages, so the table has interior pages to walk
+		{file: "multipage.sqlite", table: "Packages", want: 400},
+		{file: "rpmdb.sqlite", table: "Missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file+"/"+tt.table, func(t *testing.T) {
+			db, err := openSQLite(filepath.Join("testdata", "sqlite", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			got, err := db.countRows(tt.table)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("countRows(%q) = %d, want error", tt.table, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("countRows(%q) error: %v", tt.table, err)
+			}
+			if got != tt.want {
+				t.Errorf("countRows(%q) = %d, want %d", tt.table, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenSQLiteRejects(t *testing.T) {
+	for _, file := range []string{"not-sqlite.sqlite", "page-size-0.sqlite", "missing.sqlite"} {
+		t.Run(file, func(t *testing.T) {
+			db, err := openSQLite(filepath.Join("testdata", "sqlite", file))
+			if err == nil {
+				db.Close()
+				t.Fatal("openSQLite() succeeded, want error")
+			}
+		})
+	}
+}
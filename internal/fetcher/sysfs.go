@@ -0,0 +1,30 @@
+package fetcher
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readSysString returns the trimmed contents of a sysfs attribute, or "" if
+// the file is missing or unreadable.
+func readSysString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readSysInt parses a sysfs attribute as a base-10 integer.
+func readSysInt(path string) (int64, bool) {
+	s := readSysString(path)
+	if s == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
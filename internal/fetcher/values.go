@@ -0,0 +1,108 @@
+package fetcher
+
+import "time"
+
+// Typed module values. Modules that measure something return one of these
+// instead of a display string so the same data can drive text, JSON and
+// graph output; the ui package does the formatting.
+
+// Usage is a used/total amount of memory or disk space, in bytes.
+type Usage struct {
+	Used    uint64  `json:"used_bytes"`
+	Total   uint64  `json:"total_bytes"`
+	Percent float64 `json:"percent"`
+}
+
+// Percent is a utilisation between 0 and 100.
+type Percent float64
+
+// PackageCount is the number of packages installed by one manager.
+type PackageCount struct {
+	Manager string `json:"manager"`
+	Count   int    `json:"count"`
+}
+
+// NetworkRate is one interface's throughput over the sampling window and
+// its totals since boot.
+type NetworkRate struct {
+	Interface     string  `json:"interface"`
+	RxBytesPerSec float64 `json:"rx_bytes_per_sec"`
+	TxBytesPerSec float64 `json:"tx_bytes_per_sec"`
+	RxBytes       uint64  `json:"rx_bytes"`
+	TxBytes       uint64  `json:"tx_bytes"`
+}
+
+// Monitor is one connected display, merged from whichever source knew
+// about the output. Scale and DiagonalInches are 0 when unknown.
+type Monitor struct {
+	Connector      string  `json:"connector,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	Width          int     `json:"width"`
+	Height         int     `json:"height"`
+	RefreshHz      float64 `json:"refresh_hz,omitempty"`
+	Scale          float64 `json:"scale,omitempty"`
+	DiagonalInches float64 `json:"diagonal_inches,omitempty"`
+}
+
+// Battery is the state of every battery and, when the machine exposes one,
+// whether the AC adapter is plugged in.
+type Battery struct {
+	Batteries []BatteryStatus `json:"batteries"`
+	ACOnline  *bool           `json:"ac_online,omitempty"`
+}
+
+// BatteryStatus is one battery's charge and health. Fields the firmware
+// doesn't report are left at zero.
+type BatteryStatus struct {
+	Name               string  `json:"name"`
+	Percent            int     `json:"percent"`
+	Status             string  `json:"status"`
+	PowerW             float64 `json:"power_watts,omitempty"`
+	EnergyFullWh       float64 `json:"energy_full_wh,omitempty"`
+	EnergyFullDesignWh float64 `json:"energy_full_design_wh,omitempty"`
+	WearPercent        float64 `json:"wear_percent,omitempty"`
+	Cycles             int     `json:"cycles,omitempty"`
+	RemainingSeconds   int64   `json:"remaining_seconds,omitempty"`
+}
+
+// Sensors is one representative temperature per group (CPU, GPU, ...) and
+// every spinning fan's speed.
+type Sensors struct {
+	Temperatures []Temperature `json:"temperatures,omitempty"`
+	FanRPM       []int64       `json:"fan_rpm,omitempty"`
+}
+
+// Temperature is a single reading in degrees Celsius. Max and Crit are 0
+// when the chip doesn't expose that threshold.
+type Temperature struct {
+	Category string  `json:"category"` // CPU, GPU, NVMe, ACPI, or the raw chip name
+	Label    string  `json:"label"`
+	Celsius  float64 `json:"celsius"`
+	Max      float64 `json:"max_celsius,omitempty"`
+	Crit     float64 `json:"crit_celsius,omitempty"`
+}
+
+// empty reports whether a module value has nothing to show.
+func empty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	case []PackageCount:
+		return len(v) == 0
+	case []NetworkRate:
+		return len(v) == 0
+	case []Monitor:
+		return len(v) == 0
+	case Battery:
+		return len(v.Batteries) == 0
+	case Sensors:
+		return len(v.Temperatures) == 0 && len(v.FanRPM) == 0
+	case time.Duration:
+		return v <= 0
+	}
+	return false
+}
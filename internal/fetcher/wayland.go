@@ -0,0 +1,265 @@
+package fetcher
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// A minimal Wayland client that binds every wl_output global and records
+// the events the compositor sends for it. Like the X11 client, this avoids
+// linking libwayland or shelling out to wlr-randr.
+
+const (
+	wlDisplayID  = 1
+	wlRegistryID = 2
+
+	wlOutputModeCurrent = 0x1
+)
+
+type waylandOutput struct {
+	Name      string // Connector name, wl_output v4+
+	Make      string
+	Model     string
+	Width     int
+	Height    int
+	RefreshHz float64
+	Scale     int
+	WidthMM   int
+	HeightMM  int
+}
+
+type wlConn struct {
+	conn   net.Conn
+	r      *bufio.Reader
+	nextID uint32
+}
+
+func dialWayland() (*wlConn, error) {
+	display := os.Getenv("WAYLAND_DISPLAY")
+	if display == "" {
+		display = "wayland-0"
+	}
+	if !filepath.IsAbs(display) {
+		runtime := os.Getenv("XDG_RUNTIME_DIR")
+		if runtime == "" {
+			return nil, errors.New("XDG_RUNTIME_DIR not set")
+		}
+		display = filepath.Join(runtime, display)
+	}
+	conn, err := net.DialTimeout("unix", display, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+	return &wlConn{conn: conn, r: bufio.NewReader(conn), nextID: 3}, nil
+}
+
+func (c *wlConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *wlConn) newID() uint32 {
+	id := c.nextID
+	c.nextID++
+	return id
+}
+
+// send writes a request. args may contain uint32, int32 and string values.
+func (c *wlConn) send(object uint32, opcode uint16, args ...any) error {
+	var body []byte
+	for _, a := range args {
+		switch v := a.(type) {
+		case uint32:
+			body = binary.LittleEndian.AppendUint32(body, v)
+		case int32:
+			body = binary.LittleEndian.AppendUint32(body, uint32(v))
+		case string:
+			body = binary.LittleEndian.AppendUint32(body, uint32(len(v)+1))
+			body = append(body, v...)
+			body = append(body, 0)
+			body = append(body, make([]byte, pad4(len(v)+1))...)
+		default:
+			return fmt.Errorf("unsupported wayland argument %T", a)
+		}
+	}
+	msg := make([]byte, 8, 8+len(body))
+	binary.LittleEndian.PutUint32(msg[0:], object)
+	binary.LittleEndian.PutUint32(msg[4:], uint32(8+len(body))<<16|uint32(opcode))
+	msg = append(msg, body...)
+	_, err := c.conn.Write(msg)
+	return err
+}
+
+type wlEvent struct {
+	Object uint32
+	Opcode uint16
+	Args   []byte
+}
+
+func (c *wlConn) readEvent() (wlEvent, error) {
+	head := make([]byte, 8)
+	if _, err := io.ReadFull(c.r, head); err != nil {
+		return wlEvent{}, err
+	}
+	word := binary.LittleEndian.Uint32(head[4:])
+	size := int(word >> 16)
+	if size < 8 {
+		return wlEvent{}, errors.New("invalid wayland message size")
+	}
+	args := make([]byte, size-8)
+	if _, err := io.ReadFull(c.r, args); err != nil {
+		return wlEvent{}, err
+	}
+	return wlEvent{
+		Object: binary.LittleEndian.Uint32(head[0:]),
+		Opcode: uint16(word & 0xffff),
+		Args:   args,
+	}, nil
+}
+
+// roundtrip issues wl_display.sync and passes every event to handle until
+// the callback fires.
+func (c *wlConn) roundtrip(handle func(wlEvent)) error {
+	callback := c.newID()
+	if err := c.send(wlDisplayID, 0, callback); err != nil {
+		return err
+	}
+	for {
+		ev, err := c.readEvent()
+		if err != nil {
+			return err
+		}
+		switch {
+		case ev.Object == callback:
+			return nil
+		case ev.Object == wlDisplayID && ev.Opcode == 0:
+			d := wlArgs(ev.Args)
+			d.uint()
+			code := d.uint()
+			return fmt.Errorf("wayland error %d: %s", code, d.string())
+		default:
+			handle(ev)
+		}
+	}
+}
+
+// wlArgs decodes event arguments in order.
+type wlArgs []byte
+
+func (a *wlArgs) uint() uint32 {
+	if len(*a) < 4 {
+		return 0
+	}
+	v := binary.LittleEndian.Uint32(*a)
+	*a = (*a)[4:]
+	return v
+}
+
+func (a *wlArgs) int() int32 {
+	return int32(a.uint())
+}
+
+func (a *wlArgs) string() string {
+	n := int(a.uint())
+	if n == 0 || len(*a) < n {
+		return ""
+	}
+	s := string((*a)[:n-1])
+	skip := n + pad4(n)
+	if skip > len(*a) {
+		skip = len(*a)
+	}
+	*a = (*a)[skip:]
+	return s
+}
+
+// waylandOutputs connects to the compositor and returns the state of every
+// wl_output.
+func waylandOutputs() ([]waylandOutput, error) {
+	c, err := dialWayland()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := c.send(wlDisplayID, 1, uint32(wlRegistryID)); err != nil {
+		return nil, err
+	}
+
+	type global struct {
+		name    uint32
+		version uint32
+	}
+	var globals []global
+	err = c.roundtrip(func(ev wlEvent) {
+		if ev.Object != wlRegistryID || ev.Opcode != 0 {
+			return
+		}
+		a := wlArgs(ev.Args)
+		name := a.uint()
+		iface := a.string()
+		version := a.uint()
+		if iface == "wl_output" {
+			globals = append(globals, global{name, version})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]waylandOutput, len(globals))
+	ids := make(map[uint32]int)
+	for i, g := range globals {
+		version := g.version
+		if version > 4 {
+			version = 4
+		}
+		id := c.newID()
+		ids[id] = i
+		outputs[i].Scale = 1
+		if err := c.send(wlRegistryID, 0, g.name, "wl_output", version, id); err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.roundtrip(func(ev wlEvent) {
+		i, ok := ids[ev.Object]
+		if !ok {
+			return
+		}
+		o := &outputs[i]
+		a := wlArgs(ev.Args)
+		switch ev.Opcode {
+		case 0: // geometry
+			a.int()
+			a.int()
+			o.WidthMM = int(a.int())
+			o.HeightMM = int(a.int())
+			a.int()
+			o.Make = a.string()
+			o.Model = a.string()
+		case 1: // mode
+			flags := a.uint()
+			w, h, refresh := a.int(), a.int(), a.int()
+			if flags&wlOutputModeCurrent != 0 {
+				o.Width, o.Height = int(w), int(h)
+				o.RefreshHz = float64(refresh) / 1000
+			}
+		case 3: // scale
+			o.Scale = int(a.int())
+		case 4: // name
+			o.Name = a.string()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
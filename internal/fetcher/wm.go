@@ -0,0 +1,116 @@
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const procDir = "/proc"
+
+// waylandCompositors maps /proc/<pid>/comm values of known Wayland
+// compositors to their display names.
+var waylandCompositors = map[string]string{
+	"sway":         "sway",
+	"Hyprland":     "Hyprland",
+	"river":        "river",
+	"kwin_wayland": "KWin",
+	"mutter":       "Mutter",
+	"gnome-shell":  "Mutter",
+	"wayfire":      "Wayfire",
+	"niri":         "niri",
+	"labwc":        "labwc",
+	"weston":       "Weston",
+	"cage":         "Cage",
+	"hikari":       "hikari",
+	"dwl":          "dwl",
+}
+
+// x11WindowManagers is the /proc fallback for X sessions whose WM doesn't
+// advertise itself through EWMH.
+var x11WindowManagers = map[string]string{
+	"i3":           "i3",
+	"bspwm":        "bspwm",
+	"dwm":          "dwm",
+	"awesome":      "awesome",
+	"xmonad":       "xmonad",
+	"openbox":      "Openbox",
+	"fluxbox":      "Fluxbox",
+	"icewm":        "IceWM",
+	"xfwm4":        "Xfwm4",
+	"kwin_x11":     "KWin",
+	"marco":        "Marco",
+	"muffin":       "Muffin",
+	"herbstluftwm": "herbstluftwm",
+	"qtile":        "Qtile",
+	"spectrwm":     "spectrwm",
+	"berry":        "berry",
+	"leftwm":       "LeftWM",
+	"metacity":     "Metacity",
+}
+
+// tilingDEs are values of XDG_CURRENT_DESKTOP that actually name a window
+// manager rather than a desktop environment.
+var tilingDEs = map[string]bool{
+	"i3": true, "bspwm": true, "sway": true, "dwm": true, "awesome": true, "xmonad": true, "openbox": true,
+}
+
+func isWayland() bool {
+	return os.Getenv("WAYLAND_DISPLAY") != "" || os.Getenv("XDG_SESSION_TYPE") == "wayland"
+}
+
+// scanProcFor returns the display name of the first process under root
+// whose comm is a key in known.
+func scanProcFor(root string, known map[string]string) string {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		if !e.IsDir() || !isNumeric(e.Name()) {
+			continue
+		}
+		comm := readSysString(filepath.Join(root, e.Name(), "comm"))
+		if name, ok := known[comm]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func getWM() string {
+	if isWayland() {
+		if name := scanProcFor(procDir, waylandCompositors); name != "" {
+			return name
+		}
+	}
+
+	if display := os.Getenv("DISPLAY"); display != "" {
+		if name, err := x11WMName(display); err == nil && name != "" {
+			return name
+		}
+		if name := scanProcFor(procDir, x11WindowManagers); name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// sameWM reports whether a DE string and a WM name refer to the same thing,
+// e.g. XDG_CURRENT_DESKTOP=sway with WM sway.
+func sameWM(de, wm string) bool {
+	return de != "" && strings.EqualFold(de, wm)
+}
@@ -0,0 +1,25 @@
+package fetcher
+
+import "testing"
+
+func TestScanProcFor(t *testing.T) {
+	tests := []struct {
+		name  string
+		root  string
+		known map[string]string
+		want  string
+	}{
+		{"match", "testdata/proc", map[string]string{"sway": "Sway"}, "Sway"},
+		{"first pid in directory order", "testdata/proc", map[string]string{"sway": "Sway", "kwin_x11": "KWin"}, "KWin"},
+		{"non-pid entries ignored", "testdata/proc", map[string]string{"i3": "i3", "hyprland": "Hyprland"}, ""},
+		{"no match", "testdata/proc", map[string]string{"openbox": "Openbox"}, ""},
+		{"missing root", "testdata/no-such-proc", map[string]string{"sway": "Sway"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanProcFor(tt.root, tt.known); got != tt.want {
+				t.Errorf("scanProcFor(%q) = %q, want %q", tt.root, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package fetcher
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// getWMTheme reads the theme of the given window manager from its own
+// configuration. wm is the name reported by getWM.
+func getWMTheme(wm string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	cfgHome := os.Getenv("XDG_CONFIG_HOME")
+	if cfgHome == "" {
+		cfgHome = filepath.Join(home, ".config")
+	}
+
+	name := strings.ToLower(wm)
+	switch {
+	case strings.Contains(name, "openbox"):
+		for _, f := range []string{"rc.xml", "lxde-rc.xml", "lubuntu-rc.xml"} {
+			if t := openboxTheme(filepath.Join(cfgHome, "openbox", f)); t != "" {
+				return t
+			}
+		}
+	case strings.Contains(name, "xfwm"):
+		return xfconfProperty(filepath.Join(cfgHome, "xfce4", "xfconf", "xfce-perchannel-xml", "xfwm4.xml"), "theme")
+	case strings.Contains(name, "kwin"):
+		return kwinTheme(cfgHome)
+	case strings.Contains(name, "fluxbox"):
+		return fluxboxTheme(filepath.Join(home, ".fluxbox", "init"))
+	case strings.Contains(name, "icewm"):
+		return icewmTheme(home)
+	case strings.Contains(name, "metacity"), strings.Contains(name, "mutter"),
+		strings.Contains(name, "gnome shell"), strings.Contains(name, "marco"), strings.Contains(name, "muffin"):
+		return gsettingsKeyfileValue(cfgHome, "org/gnome/desktop/wm/preferences", "theme")
+	case strings.Contains(name, "hyprland"):
+		return tilingConfigTheme(filepath.Join(cfgHome, "hypr", "hyprland.conf"), hyprlandInclude)
+	case strings.Contains(name, "sway"):
+		return tilingConfigTheme(filepath.Join(cfgHome, "sway", "config"), swayInclude)
+	}
+	return ""
+}
+
+var openboxThemeRe = regexp.MustCompile(`(?s)<theme>.*?<name>\s*([^<]+?)\s*</name>`)
+
+func openboxTheme(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	if m := openboxThemeRe.FindSubmatch(data); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+// xfconfProperty finds <property name="key" ... value="..."/> in an xfconf
+// channel file.
+func xfconfProperty(path, key string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	re := regexp.MustCompile(`<property\s+name="` + regexp.QuoteMeta(key) + `"[^>]*\svalue="([^"]*)"`)
+	if m := re.FindSubmatch(data); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+// readINIValue returns key from [section] in an INI-style file such as
+// kwinrc. An empty section matches keys before the first header.
+func readINIValue(path, section, key string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	current := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = line[1 : len(line)-1]
+			continue
+		}
+		if current != section {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// kwinTheme reads the window decoration from kwinrc, then kdeglobals. It
+// returns "" if neither sets one.
+func kwinTheme(cfgHome string) string {
+	kwinrc := filepath.Join(cfgHome, "kwinrc")
+	if t := readINIValue(kwinrc, "org.kde.kdecoration2", "theme"); t != "" {
+		// Aurorae themes are stored as __aurorae__svg__<name>
+		return strings.TrimPrefix(t, "__aurorae__svg__")
+	}
+	if lib := readINIValue(kwinrc, "org.kde.kdecoration2", "library"); lib != "" {
+		// org.kde.breeze -> Breeze
+		name := lib[strings.LastIndex(lib, ".")+1:]
+		if name != "" {
+			return strings.ToUpper(name[:1]) + name[1:]
+		}
+	}
+	return readINIValue(filepath.Join(cfgHome, "kdeglobals"), "WM", "theme")
+}
+
+func fluxboxTheme(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), ":")
+		if ok && strings.TrimSpace(k) == "session.styleFile" {
+			return filepath.Base(strings.TrimSpace(v))
+		}
+	}
+	return ""
+}
+
+// icewmTheme reads Theme="name/default.theme" from ~/.icewm/theme or
+// ~/.icewm/preferences and returns the theme directory name.
+func icewmTheme(home string) string {
+	for _, f := range []string{"theme", "preferences"} {
+		file, err := os.Open(filepath.Join(home, ".icewm", f))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			k, v, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+			if !ok || strings.TrimSpace(k) != "Theme" {
+				continue
+			}
+			v = strings.Trim(strings.TrimSpace(v), `"`)
+			file.Close()
+			if dir, _, found := strings.Cut(v, "/"); found {
+				return dir
+			}
+			return strings.TrimSuffix(v, ".theme")
+		}
+		file.Close()
+	}
+	return ""
+}
+
+// gsettingsKeyfileValue reads a GSettings key from the keyfile backend
+// (~/.config/glib-2.0/settings/keyfile) or from system dconf keyfile
+// directories. The user's binary dconf database is not parsed.
+func gsettingsKeyfileValue(cfgHome, path, key string) string {
+	files := []string{filepath.Join(cfgHome, "glib-2.0", "settings", "keyfile")}
+	dbs, _ := filepath.Glob("/etc/dconf/db/*.d/*")
+	files = append(files, dbs...)
+
+	for _, f := range files {
+		if v := readINIValue(f, path, key); v != "" {
+			return strings.Trim(v, `'"`)
+		}
+	}
+	return ""
+}
+
+var (
+	hyprlandInclude = regexp.MustCompile(`^source\s*=\s*(.+)$`)
+	swayInclude     = regexp.MustCompile(`^include\s+(.+)$`)
+
+	// Tiling compositors have no decoration themes of their own; the
+	// theme is whatever GTK theme their config exports. The schema is
+	// often a variable, as in sway's set $gnome-schema
+	// org.gnome.desktop.interface.
+	gsettingsThemeRe = regexp.MustCompile(`gsettings\s+set\s+(\S+)\s+gtk-theme\s+['"]?([^'"\s]+)`)
+	gtkThemeEnvRe    = regexp.MustCompile(`^env\s*=\s*GTK_THEME\s*,\s*(\S+)`)
+
+	// Variables: "set $name value" in sway, "$name = value" in Hyprland
+	configVarRe = regexp.MustCompile(`^(?:set\s+(\$[\w-]+)\s+|(\$[\w-]+)\s*=\s*)(.+)$`)
+)
+
+const gnomeInterfaceSchema = "org.gnome.desktop.interface"
+
+// tilingConfigTheme searches a Hyprland or sway config, following its
+// include directives, for the GTK theme it sets.
+func tilingConfigTheme(path string, include *regexp.Regexp) string {
+	seen := make(map[string]bool)
+	vars := make(map[string]string)
+	var walk func(string) string
+	walk = func(p string) string {
+		if seen[p] || len(seen) > 32 {
+			return ""
+		}
+		seen[p] = true
+
+		f, err := os.Open(p)
+		if err != nil {
+			return ""
+		}
+		defer f.Close()
+
+		var includes []string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if m := configVarRe.FindStringSubmatch(line); m != nil {
+				vars[m[1]+m[2]] = strings.Trim(strings.TrimSpace(m[3]), `'"`)
+				continue
+			}
+			if m := gsettingsThemeRe.FindStringSubmatch(line); m != nil {
+				schema := m[1]
+				if v, ok := vars[schema]; ok {
+					schema = v
+				}
+				if schema == gnomeInterfaceSchema {
+					return m[2]
+				}
+			}
+			if m := gtkThemeEnvRe.FindStringSubmatch(line); m != nil {
+				return m[1]
+			}
+			if m := include.FindStringSubmatch(line); m != nil {
+				includes = append(includes, expandIncludePath(strings.TrimSpace(m[1]), filepath.Dir(p))...)
+			}
+		}
+		for _, inc := range includes {
+			if t := walk(inc); t != "" {
+				return t
+			}
+		}
+		return ""
+	}
+	return walk(path)
+}
+
+// expandIncludePath resolves ~, environment variables, relative paths and
+// globs in an include directive.
+func expandIncludePath(p, base string) []string {
+	p = strings.Trim(p, `"'`)
+	if strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			p = filepath.Join(home, p[2:])
+		}
+	}
+	p = os.ExpandEnv(p)
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(base, p)
+	}
+	matches, err := filepath.Glob(p)
+	if err != nil {
+		return nil
+	}
+	return matches
+}
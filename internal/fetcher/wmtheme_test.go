@@ -0,0 +1,47 @@
+package fetcher
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestTilingConfigTheme(t *testing.T) {
+	tests := []struct {
+		path    string
+		include *regexp.Regexp
+		want    string
+	}{
+		{"sway/config", swayInclude, "Adwaita-dark"},
+		{"sway-include/config", swayInclude, "Nordic"},
+		{"hypr/hyprland.conf", hyprlandInclude, "Catppuccin-Mocha"},
+		{"missing/config", swayInclude, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := tilingConfigTheme(filepath.Join("testdata", "wmtheme", tt.path), tt.include); got != tt.want {
+				t.Errorf("tilingConfigTheme(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKwinTheme(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"kde-aurorae", "Sweet-Dark"},
+		{"kde-library", "Oxygen"},
+		// Nothing configured, so nothing to report
+		{"kde-default", ""},
+		{"missing", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if got := kwinTheme(filepath.Join("testdata", "wmtheme", tt.dir)); got != tt.want {
+				t.Errorf("kwinTheme(%q) = %q, want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
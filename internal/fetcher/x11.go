@@ -0,0 +1,386 @@
+package fetcher
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A minimal X11 client that speaks just enough of the core protocol to read
+// window properties, so WM detection doesn't need xprop or cgo bindings.
+
+const (
+	x11OpInternAtom  = 16
+	x11OpGetProperty = 20
+
+	x11AtomNone    = 0
+	x11AtomAny     = 0
+	x11AtomString  = 31
+	x11AtomWindow  = 33
+	x11AtomWMName  = 39
+	x11FamilyLocal = 256
+	x11FamilyWild  = 65535
+)
+
+type x11Screen struct {
+	Root     uint32
+	Width    uint16
+	Height   uint16
+	WidthMM  uint16
+	HeightMM uint16
+}
+
+type x11Conn struct {
+	conn    net.Conn
+	r       *bufio.Reader
+	seq     uint16
+	screens []x11Screen
+}
+
+// parseDisplay splits a DISPLAY value like ":0.0" or "host:1" into host and
+// display number.
+func parseDisplay(display string) (host string, num string, err error) {
+	i := strings.LastIndex(display, ":")
+	if i < 0 {
+		return "", "", fmt.Errorf("invalid DISPLAY %q", display)
+	}
+	host = display[:i]
+	num = display[i+1:]
+	if dot := strings.Index(num, "."); dot >= 0 {
+		num = num[:dot]
+	}
+	if _, err := strconv.Atoi(num); err != nil {
+		return "", "", fmt.Errorf("invalid DISPLAY %q", display)
+	}
+	return host, num, nil
+}
+
+func dialX11(display string) (*x11Conn, error) {
+	if display == "" {
+		return nil, errors.New("DISPLAY not set")
+	}
+
+	var conn net.Conn
+	var err error
+	var host, num string
+	if strings.HasPrefix(display, "/") {
+		// launchd-style socket path, e.g. /tmp/launch-xxx/org.xquartz:0
+		_, num, err = parseDisplay(display)
+		if err != nil {
+			return nil, err
+		}
+		conn, err = net.DialTimeout("unix", display, time.Second)
+	} else {
+		host, num, err = parseDisplay(display)
+		if err != nil {
+			return nil, err
+		}
+		if host == "" || host == "unix" {
+			socket := "/tmp/.X11-unix/X" + num
+			conn, err = net.DialTimeout("unix", socket, time.Second)
+			if err != nil {
+				// Abstract namespace socket used when /tmp isn't shared
+				conn, err = net.DialTimeout("unix", "@"+socket, time.Second)
+			}
+		} else {
+			n, _ := strconv.Atoi(num)
+			conn, err = net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(6000+n)), time.Second)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &x11Conn{conn: conn, r: bufio.NewReader(conn)}
+	_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	authName, authData := readXauthority(host, num)
+	if err := c.setup(authName, authData); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *x11Conn) Close() error {
+	return c.conn.Close()
+}
+
+func pad4(n int) int {
+	return (4 - n%4) % 4
+}
+
+// setup performs the connection handshake and parses the screen list.
+func (c *x11Conn) setup(authName string, authData []byte) error {
+	req := make([]byte, 12)
+	req[0] = 'l' // little-endian
+	binary.LittleEndian.PutUint16(req[2:], 11)
+	binary.LittleEndian.PutUint16(req[4:], 0)
+	binary.LittleEndian.PutUint16(req[6:], uint16(len(authName)))
+	binary.LittleEndian.PutUint16(req[8:], uint16(len(authData)))
+	req = append(req, authName...)
+	req = append(req, make([]byte, pad4(len(authName)))...)
+	req = append(req, authData...)
+	req = append(req, make([]byte, pad4(len(authData)))...)
+	if _, err := c.conn.Write(req); err != nil {
+		return err
+	}
+
+	head := make([]byte, 8)
+	if _, err := io.ReadFull(c.r, head); err != nil {
+		return err
+	}
+	body := make([]byte, int(binary.LittleEndian.Uint16(head[6:]))*4)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return err
+	}
+	if head[0] != 1 {
+		reason := body
+		if head[0] == 0 && int(head[1]) <= len(body) {
+			reason = body[:head[1]]
+		}
+		return fmt.Errorf("x11 connection refused: %s", strings.TrimSpace(string(reason)))
+	}
+	if len(body) < 32 {
+		return errors.New("x11 setup reply too short")
+	}
+
+	vendorLen := int(binary.LittleEndian.Uint16(body[16:]))
+	numScreens := int(body[20])
+	numFormats := int(body[21])
+	off := 32 + vendorLen + pad4(vendorLen) + numFormats*8
+
+	for i := 0; i < numScreens; i++ {
+		if off+40 > len(body) {
+			return errors.New("x11 setup reply truncated")
+		}
+		s := body[off:]
+		c.screens = append(c.screens, x11Screen{
+			Root:     binary.LittleEndian.Uint32(s[0:]),
+			Width:    binary.LittleEndian.Uint16(s[20:]),
+			Height:   binary.LittleEndian.Uint16(s[22:]),
+			WidthMM:  binary.LittleEndian.Uint16(s[24:]),
+			HeightMM: binary.LittleEndian.Uint16(s[26:]),
+		})
+		// Skip the allowed depths and their visuals
+		numDepths := int(s[39])
+		off += 40
+		for d := 0; d < numDepths; d++ {
+			if off+8 > len(body) {
+				return errors.New("x11 setup reply truncated")
+			}
+			numVisuals := int(binary.LittleEndian.Uint16(body[off+2:]))
+			off += 8 + numVisuals*24
+		}
+	}
+	if len(c.screens) == 0 {
+		return errors.New("x11 server reported no screens")
+	}
+	return nil
+}
+
+// request sends a request and waits for its reply, skipping any events.
+func (c *x11Conn) request(req []byte) ([]byte, error) {
+	if _, err := c.conn.Write(req); err != nil {
+		return nil, err
+	}
+	c.seq++
+	for {
+		head := make([]byte, 32)
+		if _, err := io.ReadFull(c.r, head); err != nil {
+			return nil, err
+		}
+		switch head[0] {
+		case 0:
+			return nil, fmt.Errorf("x11 error code %d", head[1])
+		case 1:
+			extra := make([]byte, int(binary.LittleEndian.Uint32(head[4:]))*4)
+			if _, err := io.ReadFull(c.r, extra); err != nil {
+				return nil, err
+			}
+			if binary.LittleEndian.Uint16(head[2:]) != c.seq {
+				continue
+			}
+			return append(head, extra...), nil
+		}
+		// Anything else is an event, which we never asked for but may get
+	}
+}
+
+func (c *x11Conn) internAtom(name string) (uint32, error) {
+	n := len(name)
+	req := make([]byte, 8, 8+n+pad4(n))
+	req[0] = x11OpInternAtom
+	req[1] = 1 // only-if-exists
+	binary.LittleEndian.PutUint16(req[2:], uint16(2+(n+pad4(n))/4))
+	binary.LittleEndian.PutUint16(req[4:], uint16(n))
+	req = append(req, name...)
+	req = append(req, make([]byte, pad4(n))...)
+
+	reply, err := c.request(req)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(reply[8:]), nil
+}
+
+// getProperty returns the raw value of a window property, or nil if it is
+// unset. Values longer than 64KiB are truncated.
+func (c *x11Conn) getProperty(window, property, propType uint32) ([]byte, error) {
+	req := make([]byte, 24)
+	req[0] = x11OpGetProperty
+	binary.LittleEndian.PutUint16(req[2:], 6)
+	binary.LittleEndian.PutUint32(req[4:], window)
+	binary.LittleEndian.PutUint32(req[8:], property)
+	binary.LittleEndian.PutUint32(req[12:], propType)
+	binary.LittleEndian.PutUint32(req[16:], 0)
+	binary.LittleEndian.PutUint32(req[20:], 16384)
+
+	reply, err := c.request(req)
+	if err != nil {
+		return nil, err
+	}
+	format := int(reply[1])
+	if binary.LittleEndian.Uint32(reply[8:]) == x11AtomNone || format == 0 {
+		return nil, nil
+	}
+	n := int(binary.LittleEndian.Uint32(reply[16:])) * format / 8
+	if 32+n > len(reply) {
+		return nil, errors.New("x11 property reply truncated")
+	}
+	return reply[32 : 32+n], nil
+}
+
+// getPropertyAtom is getProperty for a property named by string.
+func (c *x11Conn) getPropertyAtom(window uint32, name string, propType uint32) ([]byte, error) {
+	atom, err := c.internAtom(name)
+	if err != nil || atom == x11AtomNone {
+		return nil, err
+	}
+	return c.getProperty(window, atom, propType)
+}
+
+// readXauthority finds a MIT-MAGIC-COOKIE-1 entry for the given display in
+// $XAUTHORITY or ~/.Xauthority. It returns empty values if none matches,
+// which still works for servers that don't require auth.
+func readXauthority(host, num string) (string, []byte) {
+	path := os.Getenv("XAUTHORITY")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil
+		}
+		path = filepath.Join(home, ".Xauthority")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil
+	}
+
+	hostname := host
+	if hostname == "" || hostname == "unix" {
+		hostname, _ = os.Hostname()
+	}
+
+	readField := func() ([]byte, bool) {
+		if len(data) < 2 {
+			return nil, false
+		}
+		n := int(binary.BigEndian.Uint16(data))
+		if len(data) < 2+n {
+			return nil, false
+		}
+		f := data[2 : 2+n]
+		data = data[2+n:]
+		return f, true
+	}
+
+	for len(data) >= 2 {
+		family := binary.BigEndian.Uint16(data)
+		data = data[2:]
+		addr, ok1 := readField()
+		number, ok2 := readField()
+		name, ok3 := readField()
+		cookie, ok4 := readField()
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			break
+		}
+		if family != x11FamilyWild && !(family == x11FamilyLocal && string(addr) == hostname) {
+			continue
+		}
+		if len(number) > 0 && string(number) != num {
+			continue
+		}
+		if string(name) == "MIT-MAGIC-COOKIE-1" {
+			return string(name), cookie
+		}
+	}
+	return "", nil
+}
+
+// x11WMName asks the X server for the name the running window manager
+// advertises through EWMH's _NET_SUPPORTING_WM_CHECK window.
+func x11WMName(display string) (string, error) {
+	c, err := dialX11(display)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	root := c.screens[0].Root
+	check, err := c.getPropertyAtom(root, "_NET_SUPPORTING_WM_CHECK", x11AtomWindow)
+	if err != nil {
+		return "", err
+	}
+	if len(check) < 4 {
+		return "", errors.New("no EWMH-compliant window manager")
+	}
+	wmWindow := binary.LittleEndian.Uint32(check)
+
+	utf8, err := c.internAtom("UTF8_STRING")
+	if err != nil {
+		return "", err
+	}
+	name, err := c.getPropertyAtom(wmWindow, "_NET_WM_NAME", utf8)
+	if err != nil {
+		return "", err
+	}
+	if len(name) == 0 {
+		name, err = c.getProperty(wmWindow, x11AtomWMName, x11AtomAny)
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimRight(string(name), "\x00"), nil
+}
+
+// x11XftDPI reads Xft.dpi from the RESOURCE_MANAGER property on the root
+// window, which is where xrdb stores ~/.Xresources.
+func x11XftDPI(display string) (float64, error) {
+	c, err := dialX11(display)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	data, err := c.getPropertyAtom(c.screens[0].Root, "RESOURCE_MANAGER", x11AtomString)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		k, v, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(k) != "Xft.dpi" {
+			continue
+		}
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+	return 0, errors.New("no Xft.dpi resource")
+}
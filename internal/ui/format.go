@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"pulsefetch/config"
+	"pulsefetch/internal/fetcher"
+)
+
+// timedOutText is shown in place of a module that missed its deadline.
+const timedOutText = "(timed out)"
+
+// FormatValue turns a module's value into its display lines. The
+// show_battery_usage and show_sensors_usage options add detail to the
+// battery and sensors lines.
+func FormatValue(cfg *config.Config, name string, v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case time.Duration:
+		return []string{formatDuration(v)}
+	case fetcher.Percent:
+		return []string{fmt.Sprintf("%.1f%%", float64(v))}
+	case fetcher.Usage:
+		return []string{formatUsage(name, v)}
+	case []fetcher.PackageCount:
+		counts := make([]string, len(v))
+		for i, c := range v {
+			counts[i] = fmt.Sprintf("%d (%s)", c.Count, c.Manager)
+		}
+		return []string{strings.Join(counts, ", ")}
+	case []fetcher.NetworkRate:
+		lines := make([]string, len(v))
+		for i, r := range v {
+			lines[i] = fmt.Sprintf("%s: ↓ %s/s ↑ %s/s (%s / %s total)",
+				r.Interface, formatBytes(r.RxBytesPerSec), formatBytes(r.TxBytesPerSec),
+				formatBytes(float64(r.RxBytes)), formatBytes(float64(r.TxBytes)))
+		}
+		return lines
+	case []fetcher.Monitor:
+		lines := make([]string, len(v))
+		for i, m := range v {
+			lines[i] = formatMonitor(m)
+		}
+		return lines
+	case fetcher.Battery:
+		return []string{formatBattery(v, cfg.Show("battery_usage", false))}
+	case fetcher.Sensors:
+		return []string{formatSensors(v, cfg.Show("sensors_usage", false))}
+	case nil:
+		return nil
+	}
+	return []string{fmt.Sprint(v)}
+}
+
+func formatDuration(d time.Duration) string {
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	return fmt.Sprintf("%d hours, %d mins", h, m)
+}
+
+// formatUsage shows disks in whole GiB and everything else in MiB.
+func formatUsage(name string, u fetcher.Usage) string {
+	if name == "disk" {
+		return fmt.Sprintf("%vGiB / %vGiB", u.Used>>30, u.Total>>30)
+	}
+	return fmt.Sprintf("%vMiB / %vMiB", u.Used>>20, u.Total>>20)
+}
+
+func formatMonitor(m fetcher.Monitor) string {
+	s := fmt.Sprintf("%dx%d", m.Width, m.Height)
+	if m.RefreshHz > 0 {
+		s += fmt.Sprintf(" @ %s Hz", strconv.FormatFloat(roundTo(m.RefreshHz, 2), 'f', -1, 64))
+	}
+	if m.Scale > 0 && m.Scale != 1 {
+		s += fmt.Sprintf(", %sx scale", strconv.FormatFloat(roundTo(m.Scale, 2), 'f', -1, 64))
+	}
+	if m.Connector != "" {
+		s = m.Connector + ": " + s
+	}
+	var details []string
+	if m.Name != "" {
+		details = append(details, m.Name)
+	}
+	if m.DiagonalInches > 0 {
+		details = append(details, fmt.Sprintf("%.0f\"", m.DiagonalInches))
+	}
+	if len(details) > 0 {
+		s += " (" + strings.Join(details, ", ") + ")"
+	}
+	return s
+}
+
+func roundTo(v float64, places int) float64 {
+	p := 1.0
+	for i := 0; i < places; i++ {
+		p *= 10
+	}
+	return float64(int64(v*p+0.5)) / p
+}
+
+// formatBattery lists each battery's charge and the AC state, with power
+// draw, time remaining and wear when details is set.
+func formatBattery(b fetcher.Battery, details bool) string {
+	var parts []string
+	for _, bat := range b.Batteries {
+		s := fmt.Sprintf("%d%% [%s]", bat.Percent, bat.Status)
+		if len(b.Batteries) > 1 {
+			s = bat.Name + " " + s
+		}
+		if details {
+			if d := batteryDetails(bat); d != "" {
+				s += " (" + d + ")"
+			}
+		}
+		parts = append(parts, s)
+	}
+	if b.ACOnline != nil {
+		if *b.ACOnline {
+			parts = append(parts, "AC connected")
+		} else {
+			parts = append(parts, "AC disconnected")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func batteryDetails(b fetcher.BatteryStatus) string {
+	var parts []string
+	if b.PowerW > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f W", b.PowerW))
+	}
+	if b.RemainingSeconds > 0 {
+		dir := "to empty"
+		if b.Status == "Charging" {
+			dir = "to full"
+		}
+		d := time.Duration(b.RemainingSeconds) * time.Second
+		h := int(d / time.Hour)
+		m := int((d % time.Hour) / time.Minute)
+		parts = append(parts, fmt.Sprintf("%dh %02dm %s", h, m, dir))
+	}
+	if b.EnergyFullWh > 0 && b.EnergyFullDesignWh > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f/%.1f Wh", b.EnergyFullWh, b.EnergyFullDesignWh))
+		parts = append(parts, fmt.Sprintf("%.1f%% wear", b.WearPercent))
+	}
+	if b.Cycles > 0 {
+		parts = append(parts, fmt.Sprintf("%d cycles", b.Cycles))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSensors shows one temperature per group and the fan speeds, with
+// each reading's thresholds when usage is set.
+func formatSensors(s fetcher.Sensors, usage bool) string {
+	var parts []string
+	for _, t := range s.Temperatures {
+		p := fmt.Sprintf("%s %.0f°C", t.Category, t.Celsius)
+		if usage {
+			if th := thresholds(t); th != "" {
+				p += " (" + th + ")"
+			}
+		}
+		parts = append(parts, p)
+	}
+	if len(s.FanRPM) > 0 {
+		rpms := make([]string, len(s.FanRPM))
+		for i, f := range s.FanRPM {
+			rpms[i] = strconv.FormatInt(f, 10)
+		}
+		label := "Fan"
+		if len(s.FanRPM) > 1 {
+			label = "Fans"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s RPM", label, strings.Join(rpms, "/")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func thresholds(t fetcher.Temperature) string {
+	var parts []string
+	if t.Max > 0 {
+		parts = append(parts, fmt.Sprintf("max %.0f°C", t.Max))
+	}
+	if t.Crit > 0 {
+		parts = append(parts, fmt.Sprintf("crit %.0f°C", t.Crit))
+	}
+	limit := t.Crit
+	if limit <= 0 {
+		limit = t.Max
+	}
+	if limit > 0 {
+		parts = append(parts, fmt.Sprintf("%.0f%%", t.Celsius*100/limit))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatBytes renders a byte count using binary prefixes.
+func formatBytes(b float64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%.0f B", b)
+	}
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := -1
+	for b >= unit && i < len(units)-1 {
+		b /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", b, units[i])
+}
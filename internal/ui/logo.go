@@ -1,10 +1,16 @@
 package ui
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 
-	"pulsefetch/internal/config"
+	"pulsefetch/config"
 	"pulsefetch/internal/fetcher"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Default "Pulse" Logo (Electric Shock / Pulse Wave)
@@ -19,6 +25,7 @@ const defaultLogo = `
     |      |
     |______|
 `
+
 // A better pulse wave ASCII
 const pulseLogo = `
        _     
@@ -51,21 +58,157 @@ const boltLogo = `
   |_____|
 `
 
-func GetLogo(cfg *config.Config, info *fetcher.SystemInfo) string {
+// pulseLogos are pulsefetch's own logos, selectable by name like the
+// distro logos.
+var pulseLogos = map[string]string{
+	"electric": electricLogo,
+	"pulse":    pulseLogo,
+	"bolt":     boltLogo,
+	"classic":  defaultLogo,
+}
+
+// logoColor is used for logos without colour markers when the theme
+// doesn't set one.
+const logoColor = "39"
+
+// GetLogo returns the logo to draw: the image if one is set and renders,
+// else cfg.Logo as a logo name or a file of ASCII art. It fails for a logo
+// that is neither; "auto" falls back to pulsefetch's own logo.
+func GetLogo(cfg *config.Config, info *fetcher.SystemInfo) (string, error) {
 	if cfg.ImageMode == "none" {
-		return ""
+		return "", nil
 	}
 
 	if cfg.ImagePath != "" {
 		// Try to load image
 		if _, err := os.Stat(cfg.ImagePath); err == nil {
-			logo, _, _, err := RenderImage(cfg.ImagePath, info.Terminal)
+			logo, _, _, err := RenderImage(cfg.ImagePath, info.Get("terminal"))
 			if err == nil {
-				return logo
+				return logo, nil
 			}
 		}
 	}
 
+	// A path is always a file of ASCII art; a bare word is a logo name
+	// first, so a file called "debian" doesn't shadow the Debian logo
+	if strings.ContainsRune(cfg.Logo, os.PathSeparator) {
+		return readLogoFile(cfg.Logo)
+	}
+
+	name := strings.ToLower(cfg.Logo)
+	small := cfg.LogoSize == "small"
+	if trimmed, ok := strings.CutSuffix(name, "_small"); ok {
+		name = trimmed
+		small = true
+	}
+
+	if art, ok := pulseLogos[name]; ok {
+		return colorizeLogo(art, themeLogoColors(nil)), nil
+	}
+
+	// "auto" picks by os-release ID, then each ID_LIKE in order
+	auto := name == "" || name == "auto"
+	candidates := []string{name}
+	if auto {
+		candidates = append([]string{info.OS.ID}, info.OS.IDLike...)
+	}
+	if logo, ok := findDistroLogo(candidates); ok {
+		art := logo.Art
+		if small {
+			art = logo.Small
+		}
+		return colorizeLogo(art, themeLogoColors(logo.Colors)), nil
+	}
+	if !auto {
+		if st, err := os.Stat(cfg.Logo); err == nil && !st.IsDir() {
+			return readLogoFile(cfg.Logo)
+		}
+		return "", fmt.Errorf("unknown logo %q (built-in logos: %s)", cfg.Logo, strings.Join(logoNames(), ", "))
+	}
+
 	// Fallback to default ASCII
-	return electricLogo
-}
\ No newline at end of file
+	return colorizeLogo(electricLogo, themeLogoColors(nil)), nil
+}
+
+// logoNames lists the built-in logo names, sorted.
+func logoNames() []string {
+	var names []string
+	for name := range pulseLogos {
+		names = append(names, name)
+	}
+	for name := range distroLogos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readLogoFile reads a text file of ASCII art, which may use ${n} colour
+// markers.
+func readLogoFile(path string) (string, error) {
+	art, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("logo: %w", err)
+	}
+	return colorizeLogo(strings.TrimRight(string(art), "\n"), themeLogoColors(nil)), nil
+}
+
+// themeLogoColors draws the logo in the theme's logo colour if it sets one,
+// otherwise in the logo's own colours.
+func themeLogoColors(own []string) []string {
+	if currentTheme.Logo != "" {
+		return []string{currentTheme.Logo}
+	}
+	return own
+}
+
+func findDistroLogo(ids []string) (distroLogo, bool) {
+	for _, id := range ids {
+		id = strings.ToLower(id)
+		if alias, ok := logoAliases[id]; ok {
+			id = alias
+		}
+		if logo, ok := distroLogos[id]; ok {
+			return logo, true
+		}
+	}
+	return distroLogo{}, false
+}
+
+// colorizeLogo replaces ${n} markers with the nth colour. Text before the
+// first marker, and logos without colours, use logoColor.
+func colorizeLogo(art string, colors []string) string {
+	current := logoColor
+	if len(colors) > 0 {
+		current = colors[0]
+	}
+
+	lines := strings.Split(art, "\n")
+	for i, line := range lines {
+		var b strings.Builder
+		for {
+			start := strings.Index(line, "${")
+			end := strings.Index(line[max(start, 0):], "}")
+			if start < 0 || end < 0 {
+				break
+			}
+			end += start
+			if start > 0 {
+				b.WriteString(logoSegment(current, line[:start]))
+			}
+			if n, err := strconv.Atoi(line[start+2 : end]); err == nil && n >= 1 && n <= len(colors) {
+				current = colors[n-1]
+			}
+			line = line[end+1:]
+		}
+		if line != "" {
+			b.WriteString(logoSegment(current, line))
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func logoSegment(color, text string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(text)
+}
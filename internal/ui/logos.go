@@ -0,0 +1,616 @@
+package ui
+
+// Built-in distro logos. ${1}..${6} switch to the logo's nth colour until
+// the next marker; Colors holds ANSI 256 indexes for each.
+
+type distroLogo struct {
+	Art    string
+	Small  string
+	Colors []string
+}
+
+var distroLogos = map[string]distroLogo{
+	"arch": {
+		Colors: []string{"39", "39"},
+		Art: `${1}                  -'
+                 .o+'
+                'ooo/
+               '+oooo:
+              '+oooooo:
+              -+oooooo+:
+            '/:-:++oooo+:
+           '/++++/+++++++:
+          '/++++++++++++++:
+         '/+++ooooooooooooo/'
+        ./ooosssso++osssssso+'
+       .oossssso-''''/ossssss+'
+      -osssssso.      :ssssssso.
+     :osssssss/        osssso+++.
+    /ossssssss/        +ssssooo/-
+  '/ossssso+/:-        -:/+osssso+-
+ '+sso+:-'                 '.-/+oso:
+'++:.                           '-/+/
+.'                                 '/`,
+		Small: `${1}      /\
+     /  \
+    /\   \
+   /      \
+  /   ,,   \
+ /   |  |  -\
+/_-''    ''-_\`,
+	},
+	"debian": {
+		Colors: []string{"161", "255"},
+		Art: `${2}       _,met$$$$$$$$$$gg.
+    ,g$$$$$$$$$$$$$$$P.
+  ,g$$P"     """Y$$.".
+ ,$$P'              '$$$.
+',$$P       ,ggs.     '$$b:
+'d$$'     ,$P"'   ${1}.${2}    $$$
+ $$P      d$'     ${1},${2}    $$P
+ $$:      $$.   ${1}-${2}    ,d$$'
+ $$;      Y$b._   _,d$P'
+ Y$$.    ${1}'.${2}'"Y$$$$P"'
+ '$$b      ${1}"-.__
+  ${2}'Y$$
+   'Y$$.
+     '$$b.
+       'Y$$b.
+          '"Y$b._
+              '"""`,
+		Small: `${1}  _____
+ /  __ \
+|  /    |
+|  \___-
+-_
+  --_`,
+	},
+	"ubuntu": {
+		Colors: []string{"202", "255"},
+		Art: `${1}            .-/+oossssoo+/-.
+        ':+ssssssssssssssssss+:'
+      -+ssssssssssssssssssyyssss+-
+    .ossssssssssssssssss${2}dMMMNy${1}sssso.
+   /sssssssssss${2}hdmmNNmmyNMMMMh${1}ssssss/
+  +sssssssss${2}hmydMMMMMMMNddddy${1}ssssssss+
+ /ssssssss${2}hNMMMyhhyyyyhmNMMMNh${1}ssssssss/
+.ssssssss${2}dMMMNh${1}ssssssssss${2}hNMMMd${1}ssssssss.
++sss${2}hhhyNMMNy${1}ssssssssssss${2}yNMMMy${1}sssssss+
+oss${2}yNMMMNyMMh${1}ssssssssssssss${2}hmmmh${1}ssssssso
+oss${2}yNMMMNyMMh${1}ssssssssssssss${2}hmmmh${1}ssssssso
++sss${2}hhhyNMMNy${1}ssssssssssss${2}yNMMMy${1}sssssss+
+.ssssssss${2}dMMMNh${1}ssssssssss${2}hNMMMd${1}ssssssss.
+ /ssssssss${2}hNMMMyhhyyyyhdNMMMNh${1}ssssssss/
+  +sssssssss${2}dmydMMMMMMMMddddy${1}ssssssss+
+   /sssssssssss${2}hdmNNNNmyNMMMMh${1}ssssss/
+    .ossssssssssssssssss${2}dMMMNy${1}sssso.
+      -+sssssssssssssssss${2}yyy${1}ssss+-
+        ':+ssssssssssssssssss+:'
+            .-/+oossssoo+/-.`,
+		Small: `${1}         _
+     ---(_)
+ _/  ---  \
+(_) |   |
+  \  --- _/
+     ---(_)`,
+	},
+	"fedora": {
+		Colors: []string{"33", "255"},
+		Art: `${1}             .',;::::;,'.
+         .';:cccccccccccc:;,.
+      .;cccccccccccccccccccccc;.
+    .:cccccccccccccccccccccccccc:.
+  .;ccccccccccccc;${2}.:dddl:.${1};ccccccc;.
+ .:ccccccccccccc;${2}OWMKOOXMWd${1};ccccccc:.
+.:ccccccccccccc;${2}KMMc${1};cc;${2}xMMc${1};ccccccc:.
+,cccccccccccccc;${2}MMM.${1};cc;${2};WW:${1};cccccccc,
+:cccccccccccccc;${2}MMM.${1};cccccccccccccccc:
+:ccccccc;${2}oxOOOo${1};${2}MMM0OOk.${1};cccccccccccc:
+cccccc;${2}0MMKxdd:${1};${2}MMMkddc.${1};cccccccccccc;
+ccccc;${2}XM0'${1};cccc;${2}MMM.${1};cccccccccccccccc'
+ccccc;${2}MMo${1};ccccc;${2}MMW.${1};ccccccccccccccc;
+ccccc;${2}0MNc.${1}ccc${2}.xMMd${1};ccccccccccccccc;
+cccccc;${2}dNMWXXXWM0:${1};cccccccccccccc:,
+cccccccc;${2}.:odl:.${1};cccccccccccccc:,.
+:cccccccccccccccccccccccccccc:'.
+.:cccccccccccccccccccccc:;,..`,
+		Small: `${1}        ,'''''.
+       |   ,.  |
+       |  |  '_'
+  ,....|  |..
+.'  ,_;|   ..'
+|  |   |  |
+|  ',_,'  |
+ '.     ,'
+   '''''`,
+	},
+	"nixos": {
+		Colors: []string{"110", "67"},
+		Art: `${1}          ::::.    ${2}':::::     ::::'
+${1}          '::::     ${2}':::::  ::::'
+${1}            :::::     ${2}':::::::'
+${1}      .....:::::${2}.......::::::
+${1}     ::::::::::::::::::${2}':::::     ${1}.
+${2}           ::::::'        ${2}':::::   ${1}.:::
+${2}          ::::::            ${2}'::::${1}.:::::
+${2}  .:::::::::::             ${1}.::::::::::::
+${2}  ':::::::::'             ${1}:::::::::::'
+${2}        .::::.            ${1}::::::
+${2}       :::::::.          ${1}::::::
+${2}        ':::::'${1}..........::::::::::::
+${2}         '::: ${1}'::::::::::::::::::
+${2}           ' ${1}  ::::::     ::::::
+${1}              .:::::::::.    ::::::
+${1}             ::::'  ':::::   ':::'`,
+		Small: `${1}  \\  \\ //
+ ==\\__\\/ //
+   //   \\//
+==//     //==
+ //\\___//
+// /\\  \\==
+  // \\  \\`,
+	},
+	"gentoo": {
+		Colors: []string{"140", "255"},
+		Art: `${1}         -/oyddmdhs+:.
+     -o${2}dNMMMMMMMMNNmhy+${1}-'
+   -y${2}NMMMMMMMMMMMNNNmmdhy${1}+-
+ 'o${2}mMMMMMMMMMMMMNmdmmmmddhhy${1}/'
+ om${2}MMMMMMMMMMMN${1}hhyyyo${2}hmdddhhhd${1}o'
+.y${2}dMMMMMMMMMMd${1}hs++so/s${2}mdddhhhhdm${1}+'
+ oy${2}hdmNMMMMMMMN${1}dyooy${2}dmddddhhhhyhN${1}d.
+  :o${2}yhhdNNMMMMMMMNNNmmdddhhhhhyym${1}Mh
+    .:${2}+sydNMMMMMNNNmmmdddhhhhhhmM${1}my
+       /m${2}MMMMMMNNNmmmdddhhhhhmMNh${1}s:
+    'o${2}NMMMMMMMNNNmmmddddhhdmMNhs${1}+'
+  'sy${2}MMMMMMMMNNNmmmdddddmNMmhs${1}/.
+ /N${2}MMMMMMMMNNNNmmmdddmNMNdso${1}:'
++M${2}MMMMMMNNNNNmmmmdmNMNdso${1}/-
+yM${2}MNNNNNNNmmmmmNNMmhs+/${1}-'
+/h${2}MMNNNNNNNNMNdhs++/${1}-'
+'/${2}ohdmmddhys+++/:${1}.'
+  '-//////:--.`,
+		Small: `${1} _-----_
+(       \
+\    0   \
+${2} \        )
+ /      _/
+(     _-
+\____-`,
+	},
+	"opensuse": {
+		Colors: []string{"113", "255"},
+		Art: `${2}           .;ldkO0000Okdl;.
+       .;d00xl:^''''''^:ok00d;.
+     .d00l'                'o00d.
+   .d0Kd'${1}  Okxol:;,.          ${2}:O0d.
+  .OK${1}KKK0kOKKKKKKKKKKOxo:,      ${2}lKO.
+ ,0K${1}KKKKKKKKKKKKKKK0P^${2},,,${1}^dx:${2}    ;00,
+.OK${1}KKKKKKKKKKKKKKKk'${2}.oOPPb.${1}'0k.${2}   cKO.
+:KK${1}KKKKKKKKKKKKKKK: ${2}kKx..dd ${1}lKd${2}   'OK:
+dKK${1}KKKKKKKKKOx0KKKd ${2}^0KKKO' ${1}kKKc${2}   dKd
+dKK${1}KKKKKKKKKK;.;oOKx,..${2}^${1}..;kKKK0.${2}  dKd
+:KK${1}KKKKKKKKKK0o;...^cdxxOK0O/^^'  ${2}.0K:
+ kKK${1}KKKKKKKKKKKKK0x;,,......,;od  ${2}lKk
+ '0K${1}KKKKKKKKKKKKKKKKKKKK00KKOo^  ${2}c00'
+  'kK${1}KKOxddxkOO00000Okxoc;''   ${2}.dKk'
+    l0Ko.                    .c00l'
+     'l0Kk:.              .;xK0l'
+        'lkK0xl:;,,,,;:ldO0kl'
+            '^:ldxkkkkxdl:^'`,
+		Small: `${1}  _______
+__|   __ \
+     / .\ \
+     \__/ |
+   _______|
+   \_______
+__________/`,
+	},
+	"alpine": {
+		Colors: []string{"33", "255"},
+		Art: `${1}       .hddddddddddddddddddddddh.
+      :dddddddddddddddddddddddddd:
+     /dddddddddddddddddddddddddddd/
+    +dddddddddddddddddddddddddddddd+
+  'sdddddddddddddddddddddddddddddddds'
+ 'ydddddddddddd++hdddddddddddddddddddy'
+.hddddddddddd+'  '+ddddh:-sdddddddddddh.
+hdddddddddd+'      '+y:    .sddddddddddh
+ddddddddh+'   '//'   '.'     -sddddddddd
+ddddddh+'   '/hddh/'   ':s-    -sddddddd
+ddddh+'   '/+/dddddh/'   '+s-    -sddddd
+ddd+'   '/o' :dddddddh/'   'oy-    .yddd
+hdddyo+ohddyosdddddddddho+oydddy++ohdddh
+.hddddddddddddddddddddddddddddddddddddh.
+ 'yddddddddddddddddddddddddddddddddddy'
+  'sdddddddddddddddddddddddddddddddds'
+    +dddddddddddddddddddddddddddddd+
+     /dddddddddddddddddddddddddddd/
+      :dddddddddddddddddddddddddd:
+       .hddddddddddddddddddddddh.`,
+		Small: `${1}   /\ /\
+  // \  \
+ //   \  \
+///    \  \
+//      \  \
+         \`,
+	},
+	"void": {
+		Colors: []string{"35", "238"},
+		Art: `${1}                __.;=====;.__
+            _.=+==++=++=+=+===;.
+             -=+++=+===+=+=+++++=_
+        .     -=:''     '':=+++=+=.
+       _vi,    '            --+=++++:
+      .uvnvi.       _._       -==+==+.
+     .vvnvnI'    .;==|==;.     :|=||=|.
+${2}+QmQQm${1}pvvnv; ${2}_yYsyQQWUUQQQm #QmQ#${1}:${2}QQQWUV$QQm.
+${2} -QQWQW${1}pvvo${2}wZ?.wQQQE${1}==<${2}QWWQ/QWQW.QQWW${1}(: ${2}jQWQE
+${2}  -$QQQQmmU'  jQQQ${1}@+=<${2}QWQQ)mQQQ.mQQQC${1}+;${2}jWQQ@'
+${2}   -$WQ8Y${1}nI:   ${2}QWQQwgQQWV${1}'${2}mWQQ.jQWQQgyyWW@!
+${1}     -1vvnvv.     '~+++'        ++|+++
+      +vnvnnv,                 '-|===
+       +vnvnvns.           .      :=-
+        -Invnvvnsi..___..=sv=.     '
+          +Invnvnvnnnnnnnnvvnn;.
+            ~|Invnvnvvnvvvnnv}+'
+               -~"|{*l}*|""~`,
+		Small: `${1}    _______
+ _ \______ -
+| \  ___  \ |
+| | /   \ | |
+| | \___/ | |
+| \______ \_|
+ -_______\`,
+	},
+	"mint": {
+		Colors: []string{"120", "255"},
+		Art: `${1}MMMMMMMMMMMMMMMMMMMMMMMMMmds+.
+MMm----::-://////////////oymNMd+'
+MMd      ${2}/++                ${1}-sNMd:
+MMNso/'  ${2}dMM    '.::-. .-::.' ${1}.hMN:
+ddddMMh  ${2}dMM   :hNMNMNhNMNMNh: ${1}'NMm
+    NMm  ${2}dMM  .NMN/-+MMM+-/NMN' ${1}dMM
+    NMm  ${2}dMM  -MMm  'MMM   dMM. ${1}dMM
+    NMm  ${2}dMM  -MMm  'MMM   dMM. ${1}dMM
+    NMm  ${2}dMM  .mmd  'mmm   yMM. ${1}dMM
+    NMm  ${2}dMM'  ..'   ...   ydm. ${1}dMM
+    hMM- ${2}+MMd/-------...-:sdds  ${1}dMM
+    -NMm- ${2}:hNMNNNmdddddddddy/'  ${1}dMM
+     -dMNs-${2}''-::::-------.''    ${1}dMM
+      '/dMNmy+/:-------------:/yMMM
+         ./ydNMMMMMMMMMMMMMMMMMMMMM`,
+		Small: `${1} ___________
+|_          \
+  | ${2}| _____ ${1}|
+  | ${2}| | | | ${1}|
+  | ${2}| | | | ${1}|
+  | ${2}\__${2}___/ ${1}|
+  \_________/`,
+	},
+	"pop": {
+		Colors: []string{"37", "255"},
+		Art: `${2}             /////////////
+         /////////////////////
+      ///////${1}*767${2}////////////////
+    //////${1}7676767676*${2}//////////////
+   /////${1}76767${2}//${1}7676767${2}//////////////
+  /////${1}767676${2}///${1}*76767${2}///////////////
+ ///////${1}767676${2}///${1}76767${2}.///${1}7676*${2}///////
+/////////${1}767676${2}//${1}76767${2}///${1}767676${2}////////
+//////////${1}76767676767${2}////${1}76767${2}/////////
+///////////${1}76767676${2}//////${1}7676${2}//////////
+////////////,${1}7676${2},///////${1}767${2}///////////
+/////////////*${1}7676${2}///////${1}76${2}////////////
+///////////////${1}7676${2}////////////////////
+ ///////////////${1}7676${2}///${1}767${2}////////////
+  //////////////////////${1}'${2}////////////
+   //////${1}.7676767676767676767,${2}//////
+    /////${1}767676767676767676767${2}/////
+      ///////////////////////////
+         /////////////////////
+             /////////////`,
+		Small: `${1}______
+\   _ \        __
+ \ \ \ \      / /
+  \ \_\ \    / /
+   \  ___\  /_/
+    \ \    _
+   __\_\__(_)_
+  (___________)`,
+	},
+	"manjaro": {
+		Colors: []string{"35", "35"},
+		Art: `${1}██████████████████  ████████
+██████████████████  ████████
+██████████████████  ████████
+██████████████████  ████████
+████████            ████████
+████████  ████████  ████████
+████████  ████████  ████████
+████████  ████████  ████████
+████████  ████████  ████████
+████████  ████████  ████████
+████████  ████████  ████████
+████████  ████████  ████████
+████████  ████████  ████████
+████████  ████████  ████████`,
+		Small: `${1}||||||||| ||||
+||||||||| ||||
+||||      ||||
+|||| |||| ||||
+|||| |||| ||||
+|||| |||| ||||
+|||| |||| ||||`,
+	},
+	"endeavouros": {
+		Colors: []string{"203", "99", "33"},
+		Art: `${1}                     ./${2}o${3}.
+${1}                   ./${2}sssso${3}-
+${1}                 '/${2}ossssssso${3}:
+${1}               '/${2}ossssssssssso${3}+
+${1}             '/${2}ossssssssssssssso${3}+.
+${1}           '/${2}ossssssssssssssssssso${3}+.
+${1}         './${2}ossssssssssssssssssssso${3}+/
+${1}       './${2}ossssssssssssssssssssssso${3}+:
+${1}      -/${2}ossssssssssssssssssssssssso${3}+:
+${1}    :/${2}ossssssssssssssssssssssssssso${3}+:
+${1}  '/${2}ossssssssssssssssssssssssssss${3}/-'
+${1} .//${2}osssssssssssssssssssssssss${3}+:.
+${3} '.:${2}+++++++++++++++++++++++++${3}/-'
+${3}   '...........................'`,
+		Small: `${1}          /${2}o${3}.
+${1}        /${2}sssso${3}-
+${1}      /${2}ossssssso${3}:
+${1}    /${2}ossssssssssso${3}+
+${1}  /${2}ossssssssssssssso${3}+
+${1}//${2}osssssssssssssso${3}+-
+${3}  '+++++++++++++++-'`,
+	},
+	"rhel": {
+		Colors: []string{"196", "255"},
+		Art: `${1}           .MMM..:MMMMMMM
+          MMMMMMMMMMMMMMMMMM
+          MMMMMMMMMMMMMMMMMMMM.
+         MMMMMMMMMMMMMMMMMMMMMM
+        ,MMMMMMMMMMMMMMMMMMMMMM:
+        MMMMMMMMMMMMMMMMMMMMMMMM
+  .MMMM'  MMMMMMMMMMMMMMMMMMMMMM
+ MMMMMM    'MMMMMMMMMMMMMMMMMMMM.
+MMMMMMMM      MMMMMMMMMMMMMMMMMM .
+MMMMMMMMM.       'MMMMMMMMMMMMM' MM.
+MMMMMMMMMMM.                     MMMM
+'MMMMMMMMMMMMM.                 ,MMMMM.
+ 'MMMMMMMMMMMMMMMMM.          ,MMMMMMMM.
+    MMMMMMMMMMMMMMMMMMMMMMMMMMMMMMMMMMMM
+      MMMMMMMMMMMMMMMMMMMMMMMMMMMMMMMMM:
+         MMMMMMMMMMMMMMMMMMMMMMMMMMMMMM
+            'MMMMMMMMMMMMMMMMMMMMMMMM:
+                ''MMMMMMMMMMMMMMMMM'`,
+		Small: `${1}      .M.:MMM
+     MMMMMMMMMM.
+    ,MMMMMMMMMMM
+ .MM MMMMMMMMMMM
+MMMM   MMMMMMMMM
+MMMMMM        MM
+ MMMMMMMMM   ,MMMM
+   MMMMMMMMMMMMMMMM:
+      'MMMMMMMMMMMM:`,
+	},
+	"rocky": {
+		Colors: []string{"35"},
+		Art: `${1}          __wgliliiligw_,
+       _williiiiiiliilililw,
+     _%iiiiiilililiiiiiiiiiii_
+   .Qliiiililiiiiiiililililiilm.
+  _iiiiiliiiiiililiiiiiiiiiiliil,
+ .lililiiilililiiiilililiiiiiiiil,
+ vliiiiiilililiiiiiiiililililiiiii.
+]iiiiilililiiiiiiililiilililiilil'
+iiilililiiiiiiiiliiiili?~  'lilli
+liiiiiiiilililiiilii~'  .uliilil
+ ]liiililiiiiiilii~  _iiiliiiiil
+ iliiiililiilii~' _wlililiiilil'
+  ]lililiili~' _wiliiiiiiilii'
+   ~iiiiii~' _wliliiiliiliiil'
+     ~lll!  _iiiiiiiiiliil~
+         '~~liliiiiiiil~'
+              '~~~~'`,
+		Small: `${1}    __
+ ,g@@@@@@@@p,
+j@@@@@@@@@@@@@.
+j@@@@@@*@@@@@@@@
+j@@@*'  '*@@@@@@@
+ '@@@p,,g@@@@@@@
+   '@@@@@@@@@@@'
+      "*@@@*"`,
+	},
+	"centos": {
+		Colors: []string{"220", "40", "33", "135"},
+		Art: `${1}                 ..
+               .PLTJ.
+              <><><><>
+     ${2}KKSSV' 4KKK ${1}LJ${4} KKKL.'VSSKK
+     ${2}KKV' 4KKKKK ${1}LJ${4} KKKKAL 'VKK
+     ${2}V' ' 'VKKKK ${1}LJ${4} KKKKV' ' 'V
+     ${2}.4MA.' 'VKK ${1}LJ${4} KKV' '.4Mb.
+${4}   . ${2}KKKKKA.' 'V ${1}LJ${4} V' '.4KKKKK ${3}.
+${4} .4D ${2}KKKKKKKA.'' ${1}LJ${4} ''.4KKKKKKK ${3}FA.
+${4}<QDD ++++++++++++  ${3}++++++++++++ GFD>
+${4} 'VD ${3}KKKKKKKK'.. ${2}LJ ${1}..'KKKKKKKK ${3}FV
+${4}   ' ${3}VKKKKK'. .4 ${2}LJ ${1}K. .'KKKKKV ${3}'
+     ${3} 'VK'. .4KK ${2}LJ ${1}KKA. .'KV'
+     ${3}A. . .4KKKK ${2}LJ ${1}KKKKA. . .4
+     ${3}KKA. 'KKKKK ${2}LJ ${1}KKKKK' .4KK
+     ${3}KKSSA. VKKK ${2}LJ ${1}KKKV .4SSKK
+${2}              <><><><>
+               'MKKM'
+                 ''`,
+		Small: `${2} ____${1}^${4}____
+${2} |\  ${1}|${4}  /|
+${2} | \ ${1}|${4} / |
+${4}<---- ${3}---->
+${3} | / ${2}|${1} \ |
+${3} |/__${2}|${1}__\|
+${2}     v`,
+	},
+	"almalinux": {
+		Colors: []string{"196", "220", "33", "40", "33"},
+		Art: `${1}         'c:.
+${1}        lkkkx, ..       ${2}..   ,cc,
+${1}        okkkk:ckkx'  ${2}.lxkkx.okkkkd
+${1}        .:llcokkx'  ${2}:kkkxkko:xkkd,
+${1}      .xkkkkdood:  ${2};kx,  .lkxlll;
+${1}       xkkx.       ${2}xk'     xkkkkk:
+${1}       'xkx.       ${2}xd      .....,.
+${3}      .. ${1}:xkl'     ${2}:c      ..''..
+${3}    .dkx'  ${1}.:ldl:'. ${2}'  ${4}':lollldkkxo;
+${3}  .''  ,${1}xkkkkkkkkc' ${4}.okkkkxoll;;,.
+${3}  'kkxc,.  ${1}'ckkkkx ${4}lkkk'.....
+${3}   'kkkkkx.   ${1}'kkd ${4}kkk.
+${3}    ':lkkko.   ${1}lk  ${4}kk.
+${3}       'lkkc.  ${1}::  ${4}kx
+${3}         ;kkx   ${4}   xk
+${3}           ''     ${4}  ''`,
+		Small: `${1}   _ ${2}  _
+${1}  ( \${2}/ )
+${3} __${1}\ ${2}/${4}__
+${3}(__  ${4}  __)
+${3}  / ${5}/\${4} \
+${3} (_${5}/  \${4}_)`,
+	},
+	"kali": {
+		Colors: []string{"33", "255"},
+		Art: `${1}..............
+            ..,;:ccc,.
+          ......''';lxO.
+.....''''..........,:ld;
+           .';;;:::;,,.x,
+      ..'''.            0Xxoc:,.  ...
+  ....                ,ONkc;,;cokOdc',.
+ .                   OMo           ':${2}dd${1}o.
+                    dMc               :OO;
+                    0M.                 .:o.
+                    ;Wd
+                     ;XO,
+                       ,d0Odlc;,..
+                           ..',;:cdOOd::,.
+                                    .:d;.':;.
+                                       'd,  .'
+                                         ;l   ..
+                                          .o
+                                            c
+                                            .'
+                                             .`,
+		Small: `${1}-#. #
+ @###
+ -@###
+  ##@##.
+   .+ -@##
+      '@#
+        #
+         -`,
+	},
+	"elementary": {
+		Colors: []string{"255"},
+		Art: `${1}         eeeeeeeeeeeeeeeee
+      eeeeeeeeeeeeeeeeeeeeeee
+    eeeee  eeeeeeeeeeee   eeeee
+  eeee   eeeee       eee     eeee
+ eeee   eeee          eee     eeee
+eee    eee            eee       eee
+eee   eee            eee        eee
+ee    eee           eeee       eeee
+ee    eee         eeeee      eeeeee
+ee    eee       eeeee      eeeee ee
+eee   eeee   eeeeee      eeeee  eee
+eee    eeeeeeeeee     eeeeee    eee
+ eeeeeeeeeeeeeeeeeeeeeeee    eeeee
+  eeeeeeee eeeeeeeeeeee      eeee
+    eeeee                 eeeee
+      eeeeeee         eeeeeee
+         eeeeeeeeeeeeeeeee`,
+		Small: `${1}  _______
+ / ____  \
+/  |  /  /\
+|__\ /  / |
+\   /__/  /
+ \_______/`,
+	},
+	"artix": {
+		Colors: []string{"45"},
+		Art: `${1}                   '
+                  'o'
+                 'ooo'
+                'oxoo'
+               'ooxoo'
+              'ooxxoo'
+             'oooxxoo'
+            'ooxxxxoo'
+           'ooxxxxxoo'
+          'ooxxxxoxoo-
+         'ooxxxxxooo+'
+        'ooxxxxoo-   '
+       'ooxxxoo+'      ..
+      'ooxxoo-            ..
+     'ooxoo:'                 ...
+    'oxoo:'                  ...
+   'ooo:         ..               '
+  'ooo+.                           '`,
+		Small: `${1}      /\
+     /  \
+    /'.,'\
+   /     ',
+  /      ,'
+ /   ,.'\
+/.,'     '\`,
+	},
+	"slackware": {
+		Colors: []string{"61", "255"},
+		Art: `${1}                  :::::::
+            :::::::::::::::::::
+         :::::::::::::::::::::::::
+       ::::::::${2}cllcccccllllllll${1}::::::
+    :::::::::${2}lc               dc${1}:::::::
+   ::::::::${2}cl   clllccllll    oc${1}:::::::::
+  :::::::::${2}o   lc${1}::::::::${2}co   oc${1}::::::::::
+ ::::::::::${2}o    cccclc${1}:::::${2}clcc${1}::::::::::::
+ :::::::::::${2}lc        cclccclc${1}:::::::::::::
+::::::::::::::${2}lcclcc          lc${1}::::::::::::
+::::::::::${2}cclcc${1}:::::${2}lccclc     oc${1}:::::::::::
+::::::::::${2}o    l${1}::::::::::${2}l    lc${1}:::::::::::
+ :::::${2}cll${1}:${2}o     clcllcccll     o${1}:::::::::::
+ :::::${2}occ${1}:${2}o                  clc${1}:::::::::::
+  ::::${2}ocl${1}:${2}ccslclccclclccclclc${1}:::::::::::::
+   :::${2}oclcccccccccccccllllllllllllll${1}:::::
+    ::${2}lcc1lcccccccccccccccccccccccco${1}::::
+      ::::::::::::::::::::::::::::::::
+        ::::::::::::::::::::::::::::
+           ::::::::::::::::::::::
+                ::::::::::::`,
+		Small: `${1}   ________
+  /  ______|
+  | |______
+  \______  \
+   ______| |
+| |________/
+|____________`,
+	},
+}
+
+// logoAliases maps os-release IDs to the logo they share.
+var logoAliases = map[string]string{
+	"archlinux":           "arch",
+	"linuxmint":           "mint",
+	"pop_os":              "pop",
+	"manjaro-arm":         "manjaro",
+	"opensuse-tumbleweed": "opensuse",
+	"opensuse-leap":       "opensuse",
+	"opensuse-microos":    "opensuse",
+	"suse":                "opensuse",
+	"sles":                "opensuse",
+	"redhat":              "rhel",
+	"alma":                "almalinux",
+	"elementaryos":        "elementary",
+	"endeavour":           "endeavouros",
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"pulsefetch/config"
+	"pulsefetch/module"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The colour swatches are a module drawn by ui rather than collected, so
+// show_colors, --only and --hide treat them like any other line.
+func init() {
+	module.Register(module.Module{Name: "colors", Label: "Colors", Default: false})
+	drawnModules["colors"] = renderPalette
+}
+
+// renderPalette draws the terminal's colours for the show_colors module:
+// the 16 ANSI colours as two rows of blocks or circles, or a hue gradient
+// strip. Colours outside the terminal's range are downsampled by lipgloss.
+func renderPalette(cfg *config.Config) []string {
+	width := max(cfg.PaletteWidth, 1)
+	height := max(cfg.PaletteHeight, 1)
+
+	switch strings.ToLower(cfg.PaletteStyle) {
+	case "gradient":
+		return gradientStrip(16*width, height)
+	case "circles":
+		return paletteRows("●", width, height, " ")
+	default:
+		glyph := cfg.PaletteBlock
+		if glyph == "" {
+			glyph = "█"
+		}
+		return paletteRows(glyph, width, height, "")
+	}
+}
+
+// paletteRows renders colours 0-7 and 8-15 as two rows of cells, each
+// glyph repeated width times and height lines tall.
+func paletteRows(glyph string, width, height int, gap string) []string {
+	var lines []string
+	for row := 0; row < 2; row++ {
+		var cells []string
+		for i := row * 8; i < row*8+8; i++ {
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(strconv.Itoa(i)))
+			cells = append(cells, style.Render(strings.Repeat(glyph, width)))
+		}
+		line := strings.Join(cells, gap)
+		for range height {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// gradientStrip renders a rainbow of cols cells, height lines tall.
+func gradientStrip(cols, height int) []string {
+	var b strings.Builder
+	for i := range cols {
+		hue := 360 * float64(i) / float64(cols)
+		color := lipgloss.Color(hsvToHex(hue, 0.8, 1))
+		b.WriteString(lipgloss.NewStyle().Background(color).Render(" "))
+	}
+	line := b.String()
+
+	lines := make([]string, height)
+	for i := range lines {
+		lines[i] = line
+	}
+	return lines
+}
+
+// hsvToHex converts a hue in degrees plus saturation and value in [0,1]
+// to a "#RRGGBB" colour.
+func hsvToHex(h, s, v float64) string {
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return fmt.Sprintf("#%02X%02X%02X",
+		int(math.Round((r+m)*255)), int(math.Round((g+m)*255)), int(math.Round((b+m)*255)))
+}
@@ -0,0 +1,304 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"pulsefetch/config"
+	"pulsefetch/internal/fetcher"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+// Theme is the set of colours used for the output. An empty Logo keeps
+// distro logos in their own colours.
+type Theme struct {
+	Key       string
+	Value     string
+	Title     string
+	Separator string
+	Logo      string
+}
+
+// defaultTheme matches pulsefetch's original styling.
+var defaultTheme = Theme{Key: "39", Value: "255", Title: "39", Separator: "255"}
+
+var builtinThemes = map[string]Theme{
+	"default":    defaultTheme,
+	"nord":       {Key: "#88C0D0", Value: "#D8DEE9", Title: "#81A1C1", Separator: "#4C566A", Logo: "#5E81AC"},
+	"gruvbox":    {Key: "#FABD2F", Value: "#EBDBB2", Title: "#FE8019", Separator: "#665C54", Logo: "#B8BB26"},
+	"dracula":    {Key: "#BD93F9", Value: "#F8F8F2", Title: "#FF79C6", Separator: "#6272A4", Logo: "#BD93F9"},
+	"catppuccin": {Key: "#89B4FA", Value: "#CDD6F4", Title: "#CBA6F7", Separator: "#585B70", Logo: "#89B4FA"},
+	"solarized":  {Key: "#268BD2", Value: "#93A1A1", Title: "#B58900", Separator: "#586E75", Logo: "#2AA198"},
+	"tokyonight": {Key: "#7AA2F7", Value: "#C0CAF5", Title: "#BB9AF7", Separator: "#565F89", Logo: "#7DCFFF"},
+	"monochrome": {Key: "255", Value: "250", Title: "255", Separator: "240", Logo: "255"},
+}
+
+// colorNames maps the usual terminal colour names to their ANSI indexes.
+var colorNames = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3,
+	"blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+	"bright-black": 8, "bright-red": 9, "bright-green": 10, "bright-yellow": 11,
+	"bright-blue": 12, "bright-magenta": 13, "bright-cyan": 14, "bright-white": 15,
+	"gray": 8, "grey": 8,
+}
+
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ParseColor accepts an ANSI 256 index ("39"), a hex colour ("#88c0d0")
+// or a colour name ("bright-blue") and returns it in lipgloss form.
+func ParseColor(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if hexColorRe.MatchString(s) {
+		return s, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > 255 {
+			return "", fmt.Errorf("ANSI colour %d out of range 0-255", n)
+		}
+		return strconv.Itoa(n), nil
+	}
+	name := strings.ReplaceAll(strings.ToLower(s), "_", "-")
+	if n, ok := colorNames[name]; ok {
+		return strconv.Itoa(n), nil
+	}
+	return "", fmt.Errorf("unknown colour %q (use 0-255, #rrggbb or a name like \"blue\")", s)
+}
+
+// themesDir is where user theme files live.
+func themesDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pulsefetch", "themes")
+}
+
+// ThemeNames lists the themes the theme key accepts: the built-ins and
+// every <name>.toml in the themes directory.
+func ThemeNames() []string {
+	var names []string
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	if dir := themesDir(); dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.toml"))
+		for _, f := range files {
+			name := strings.TrimSuffix(filepath.Base(f), ".toml")
+			if _, ok := builtinThemes[name]; !ok {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadThemeFile reads <name>.toml from the themes directory. Keys may sit
+// at the top level or under a [colors] table, like in pulsefetch.toml.
+func loadThemeFile(name string) (config.Colors, bool, error) {
+	dir := themesDir()
+	if dir == "" || strings.ContainsAny(name, `/\`) {
+		return config.Colors{}, false, nil
+	}
+	path := filepath.Join(dir, name+".toml")
+	if _, err := os.Stat(path); err != nil {
+		return config.Colors{}, false, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("toml")
+	if err := v.ReadInConfig(); err != nil {
+		return config.Colors{}, false, fmt.Errorf("error reading theme %s: %w", path, err)
+	}
+	sub := v
+	if v.IsSet("colors") {
+		sub = v.Sub("colors")
+	}
+	var c config.Colors
+	if err := sub.Unmarshal(&c); err != nil {
+		return config.Colors{}, false, fmt.Errorf("error decoding theme %s: %w", path, err)
+	}
+	return c, true, nil
+}
+
+// overlay replaces fields of t with the non-empty colours in c, after
+// validating them.
+func (t Theme) overlay(c config.Colors, source string) (Theme, error) {
+	fields := []struct {
+		key string
+		src string
+		dst *string
+	}{
+		{"key", c.Key, &t.Key},
+		{"value", c.Value, &t.Value},
+		{"title", c.Title, &t.Title},
+		{"separator", c.Separator, &t.Separator},
+		{"logo", c.Logo, &t.Logo},
+	}
+	for _, f := range fields {
+		if f.src == "" {
+			continue
+		}
+		color, err := ParseColor(f.src)
+		if err != nil {
+			return t, fmt.Errorf("%s: colors.%s: %w", source, f.key, err)
+		}
+		*f.dst = color
+	}
+	return t, nil
+}
+
+// distroAccents are brand colours for distros whose os-release has no
+// ANSI_COLOR, keyed like distroLogos.
+var distroAccents = map[string]string{
+	"almalinux":   "#0F4266",
+	"alpine":      "#0D597F",
+	"arch":        "#1793D1",
+	"artix":       "#10A0CC",
+	"centos":      "#932279",
+	"debian":      "#A81D33",
+	"elementary":  "#64BAFF",
+	"endeavouros": "#7F3FBF",
+	"fedora":      "#51A2DA",
+	"gentoo":      "#54487A",
+	"kali":        "#367BF0",
+	"manjaro":     "#35BF5C",
+	"mint":        "#87CF3E",
+	"nixos":       "#7EBAE4",
+	"opensuse":    "#73BA25",
+	"pop":         "#48B9C7",
+	"rhel":        "#EE0000",
+	"rocky":       "#10B981",
+	"slackware":   "#6F7DB5",
+	"ubuntu":      "#E95420",
+	"void":        "#478061",
+}
+
+// parseSGRColor extracts the foreground colour from an SGR parameter string
+// such as os-release's ANSI_COLOR ("0;38;2;23;147;209" or "1;34").
+func parseSGRColor(sgr string) (string, bool) {
+	params := strings.Split(strings.TrimSpace(sgr), ";")
+	color := ""
+	for i := 0; i < len(params); i++ {
+		n, err := strconv.Atoi(params[i])
+		if err != nil {
+			return "", false
+		}
+		switch {
+		case n == 38 && i+1 < len(params):
+			mode, _ := strconv.Atoi(params[i+1])
+			if mode == 5 && i+2 < len(params) {
+				idx, err := strconv.Atoi(params[i+2])
+				if err != nil || idx < 0 || idx > 255 {
+					return "", false
+				}
+				color = strconv.Itoa(idx)
+				i += 2
+			} else if mode == 2 && i+4 < len(params) {
+				var rgb [3]int
+				for j := range rgb {
+					v, err := strconv.Atoi(params[i+2+j])
+					if err != nil || v < 0 || v > 255 {
+						return "", false
+					}
+					rgb[j] = v
+				}
+				color = fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+				i += 4
+			} else {
+				return "", false
+			}
+		case n >= 30 && n <= 37:
+			color = strconv.Itoa(n - 30)
+		case n >= 90 && n <= 97:
+			color = strconv.Itoa(n - 90 + 8)
+		}
+	}
+	return color, color != ""
+}
+
+// autoAccent picks the distro's colour from ANSI_COLOR, falling back to the
+// brand colour table by ID and then ID_LIKE.
+func autoAccent(osr fetcher.OSRelease) (string, bool) {
+	if c, ok := parseSGRColor(osr.ANSIColor); ok {
+		return c, true
+	}
+	for _, id := range append([]string{osr.ID}, osr.IDLike...) {
+		id = strings.ToLower(id)
+		if alias, ok := logoAliases[id]; ok {
+			id = alias
+		}
+		if c, ok := distroAccents[id]; ok {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// ResolveTheme builds the theme for cfg: the named theme (a user theme file
+// wins over a built-in one of the same name), then the accent colour, then
+// the [colors] overrides.
+func ResolveTheme(cfg *config.Config, osr fetcher.OSRelease) (Theme, error) {
+	name := strings.ToLower(cfg.Theme)
+	if name == "" {
+		name = "default"
+	}
+
+	t := defaultTheme
+	if c, ok, err := loadThemeFile(name); err != nil {
+		return t, err
+	} else if ok {
+		if t, err = defaultTheme.overlay(c, "theme "+name); err != nil {
+			return t, err
+		}
+	} else if builtin, ok := builtinThemes[name]; ok {
+		t = builtin
+	} else {
+		return t, fmt.Errorf("unknown theme %q (no %s.toml in %s)", cfg.Theme, name, themesDir())
+	}
+
+	// The accent colours the title, keys and logo
+	accent := ""
+	switch strings.ToLower(cfg.Accent) {
+	case "", "none":
+	case "auto":
+		accent, _ = autoAccent(osr)
+	default:
+		c, err := ParseColor(cfg.Accent)
+		if err != nil {
+			return t, fmt.Errorf("config: accent: %w", err)
+		}
+		accent = c
+	}
+	if accent != "" {
+		t.Title, t.Key, t.Logo = accent, accent, accent
+	}
+
+	return t.overlay(cfg.Colors, "config")
+}
+
+// ApplyTheme resolves the theme for cfg and updates the output styles.
+func ApplyTheme(cfg *config.Config, info *fetcher.SystemInfo) error {
+	t, err := ResolveTheme(cfg, info.OS)
+	if err != nil {
+		return err
+	}
+	setTheme(t)
+	return nil
+}
+
+func setTheme(t Theme) {
+	currentTheme = t
+	keyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Key)).Bold(true)
+	valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Value))
+	titleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Title)).Bold(true)
+	sepStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Separator))
+}
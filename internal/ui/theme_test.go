@@ -0,0 +1,35 @@
+package ui
+
+import "testing"
+
+func TestParseSGRColor(t *testing.T) {
+	tests := []struct {
+		sgr    string
+		want   string
+		wantOK bool
+	}{
+		{"0;34", "4", true},                    // Debian-style basic colour
+		{"1;34", "4", true},                    // Bold is ignored
+		{"0;94", "12", true},                   // Bright colours map to 8-15
+		{"38;5;33", "33", true},                // 256-colour index
+		{"0;38;2;23;147;209", "#1793D1", true}, // Arch's truecolor
+		{"1;38;2;255;0;0", "#FF0000", true},
+		{" 0;32 ", "2", true},
+		{"0;34;38;5;208", "208", true}, // Last colour wins
+		{"0", "", false},               // No colour at all
+		{"1;4", "", false},
+		{"38;5;256", "", false}, // Index out of range
+		{"38;2;1;2", "", false}, // Truncated truecolor
+		{"38;9;1", "", false},   // Unknown colour mode
+		{"0;3x", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sgr, func(t *testing.T) {
+			got, ok := parseSGRColor(tt.sgr)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseSGRColor(%q) = %q, %v, want %q, %v", tt.sgr, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -4,20 +4,27 @@ import (
 	"fmt"
 	"strings"
 
-	"pulsefetch/internal/config"
+	"pulsefetch/config"
 	"pulsefetch/internal/fetcher"
+	"pulsefetch/module"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Styles for the info block, set from the theme by ApplyTheme
 var (
-	keyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true) // Removed fixed padding
-	valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255")) // White
-	logoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).PaddingRight(4)
-	titleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
-	sepStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	currentTheme = defaultTheme
+	keyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color(defaultTheme.Key)).Bold(true)
+	valueStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(defaultTheme.Value))
+	logoStyle    = lipgloss.NewStyle().PaddingRight(4) // Colours are applied per segment by GetLogo
+	titleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(defaultTheme.Title)).Bold(true)
+	sepStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color(defaultTheme.Separator))
 )
 
+// drawnModules are the modules ui draws itself below the info lines,
+// keyed by module name. They have no Collect func.
+var drawnModules = map[string]func(cfg *config.Config) []string{}
+
 type infoItem struct {
 	Key   string
 	Value string
@@ -27,44 +34,29 @@ type infoItem struct {
 func Render(cfg *config.Config, info *fetcher.SystemInfo, logo string) {
 	var items []infoItem
 
-	// Helper to collect items
-	add := func(key, value string) {
-		if value != "" {
-			items = append(items, infoItem{Key: key, Value: value})
+	var drawn []string
+	for _, m := range module.Modules() {
+		if draw, ok := drawnModules[m.Name]; ok {
+			if m.Enabled(cfg) {
+				drawn = append(drawn, "")
+				drawn = append(drawn, draw(cfg)...)
+			}
+			continue
 		}
-	}
-	addMulti := func(key string, values []string) {
-		if len(values) > 0 {
-			items = append(items, infoItem{Key: key, Multi: values})
+		values := FormatValue(cfg, m.Name, info.Values[m.Name])
+		if info.IsTimedOut(m.Name) {
+			values = []string{timedOutText}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if m.Multi {
+			items = append(items, infoItem{Key: m.Label, Multi: values})
+		} else {
+			items = append(items, infoItem{Key: m.Label, Value: values[0]})
 		}
 	}
 
-	if cfg.ShowOS { add("OS", info.OS) }
-	if cfg.ShowHost { add("Host", info.Host) }
-	if cfg.ShowKernel { add("Kernel", info.Kernel) }
-	if cfg.ShowUptime { add("Uptime", info.Uptime) }
-	if cfg.ShowPackages { add("Packages", info.Packages) }
-	if cfg.ShowShell { add("Shell", info.Shell) }
-	if cfg.ShowResolution { add("Resolution", info.Resolution) }
-	if cfg.ShowDE { add("DE", info.DE) }
-	if cfg.ShowWM { add("WM", info.WM) }
-	if cfg.ShowWMTheme { add("WM Theme", info.WMTheme) }
-	if cfg.ShowTheme { add("Theme", info.Theme) }
-	if cfg.ShowIcons { add("Icons", info.Icons) }
-	if cfg.ShowTerminal { add("Terminal", info.Terminal) }
-	if cfg.ShowCPU { add("CPU", info.CPU) }
-	if cfg.ShowCPUUsage { add("CPU Usage", info.CPUUsage) }
-	
-	if cfg.ShowGPU { addMulti("GPU", info.GPUs) }
-	
-	if cfg.ShowMemory { add("Memory", info.Memory) }
-	if cfg.ShowMemoryUsage { add("Memory Usage", info.MemoryUsage) }
-	if cfg.ShowDisk { add("Disk", info.Disk) }
-	if cfg.ShowDiskUsage { add("Disk Usage", info.DiskUsage) }
-	if cfg.ShowNetwork { add("Network", info.Network) }
-	if cfg.ShowBattery { add("Battery", info.Battery) }
-	if cfg.ShowSensors { add("Sensors", info.Sensors) }
-
 	// Calculate Max Key Length
 	maxKeyLen := 0
 	for _, item := range items {
@@ -112,6 +104,9 @@ func Render(cfg *config.Config, info *fetcher.SystemInfo, logo string) {
 		}
 	}
 
+	// Drawn modules, like the colour swatches, go under the info lines
+	infoLines = append(infoLines, drawn...)
+
 	infoBlock := lipgloss.JoinVertical(lipgloss.Left, infoLines...)
 
 	// If Image Mode (detected by config presence and non-empty logo)
@@ -139,6 +134,8 @@ func Render(cfg *config.Config, info *fetcher.SystemInfo, logo string) {
 			remaining := logoHeight - len(lines)
 			fmt.Printf("\033[%dB", remaining)
 		}
+	} else if logo == "" {
+		fmt.Println(infoBlock)
 	} else {
 		// Standard Text/ASCII Layout
 		styledLogo := logoStyle.Render(logo)
@@ -0,0 +1,69 @@
+// Package module holds the registry of info lines pulsefetch collects and
+// draws. Packages outside pulsefetch's internal tree can add their own
+// lines by calling Register from an init func.
+package module
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"pulsefetch/config"
+)
+
+// Module is one info line of the fetch output. Name is the key used in the
+// config (show_<name>) and in fetcher.SystemInfo.Values; Label is what the line is
+// titled by default.
+type Module struct {
+	Name    string
+	Label   string
+	Default bool // Enabled when the config doesn't mention show_<name>
+	Multi   bool // May produce several lines, e.g. one per GPU
+
+	// Collect returns the module's value, or nil to hide it: a string, a
+	// []string for Multi modules, or one of the typed values in package fetcher.
+	// It should honour ctx where it can; if it doesn't return in time the
+	// line is shown as timed out. Modules the ui draws itself, like the
+	// colour palette, have no Collect func and are never collected.
+	Collect func(ctx context.Context, cfg *config.Config) any
+
+	// Timeout, if set, is the least time the module needs, for modules
+	// that sample over a configured window. It extends module_timeout_ms
+	// but not fetch_timeout_ms.
+	Timeout func(cfg *config.Config) time.Duration
+
+	// Sources, if set, makes the module cacheable: its result is reused
+	// until the cache TTL expires or any of these paths changes.
+	Sources func(cfg *config.Config) []string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Module
+)
+
+// Register adds a module after the ones already registered. Registering a
+// name twice replaces the earlier module in place.
+func Register(m Module) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for i := range registry {
+		if registry[i].Name == m.Name {
+			registry[i] = m
+			return
+		}
+	}
+	registry = append(registry, m)
+}
+
+// Modules returns the registered modules in display order.
+func Modules() []Module {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Module(nil), registry...)
+}
+
+// Enabled reports whether the module is turned on in cfg.
+func (m Module) Enabled(cfg *config.Config) bool {
+	return cfg.Show(m.Name, m.Default)
+}